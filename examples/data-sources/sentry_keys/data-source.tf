@@ -0,0 +1,12 @@
+# Retrieve the active backend keys of a project
+data "sentry_keys" "backend" {
+  organization = "my-organization"
+  project      = "web-app"
+
+  name_regex = "^backend-"
+  is_active  = true
+}
+
+output "backend_dsns" {
+  value = { for key in data.sentry_keys.backend.keys : key.name => key.dsn_public }
+}
@@ -133,3 +133,47 @@ data "sentry_organization_integration" "slack" {
   provider_key = "slack"
   name         = "Slack Workspace" # Name of your Slack workspace
 }
+
+# Typed blocks are checked when planning, and use the names of the Sentry UI
+resource "sentry_issue_alert" "typed" {
+  organization = sentry_project.main.organization
+  project      = sentry_project.main.id
+  name         = "My typed issue alert"
+
+  action_match = "any"
+  filter_match = "all"
+  frequency    = 30
+
+  condition {
+    first_seen_event {}
+  }
+
+  condition {
+    event_frequency {
+      value    = 100
+      interval = "1h"
+    }
+  }
+
+  filter {
+    level {
+      match = "gte"
+      level = "error"
+    }
+  }
+
+  action {
+    notify_email {
+      target_type      = "IssueOwners"
+      fallthrough_type = "ActiveMembers"
+    }
+  }
+
+  action {
+    slack {
+      workspace = data.sentry_organization_integration.slack.internal_id
+      channel   = "#general"
+      tags      = ["environment", "level"]
+    }
+  }
+}
@@ -5,3 +5,30 @@ resource "sentry_key" "default" {
   project = "web-app"
   name    = "My Key"
 }
+
+# Create a key for the JavaScript Loader Script of a frontend
+resource "sentry_key" "loader" {
+  organization = "my-organization"
+
+  project = "web-app"
+  name    = "Loader Script"
+
+  browser_sdk_version = "7.x"
+
+  dynamic_sdk_loader_options {
+    has_performance = true
+    has_replay      = true
+  }
+}
+
+# Rotate a key by changing rotation_trigger. The previous key keeps accepting
+# events for a week, then a later apply deactivates and deletes it.
+resource "sentry_key" "rotated" {
+  organization = "my-organization"
+
+  project = "web-app"
+  name    = "Rotated Key"
+
+  rotation_trigger = "2024-01-01"
+  rotation_overlap = "168h"
+}
@@ -8,4 +8,15 @@ resource "sentry_project" "default" {
 
   platform    = "javascript"
   resolve_age = 720
+
+  # Alerts are managed with sentry_issue_alert
+  default_rules = false
+
+  options = {
+    "feedback:branding" = "false"
+    "sentry:relay_pii_config" = jsonencode({
+      rules        = {}
+      applications = { "$string" = ["@ip"] }
+    })
+  }
 }
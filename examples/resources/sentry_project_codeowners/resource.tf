@@ -0,0 +1,8 @@
+# Sync the CODEOWNERS file of the repository of a code mapping
+resource "sentry_project_codeowners" "default" {
+  organization    = "my-organization"
+  project         = "web-app"
+  code_mapping_id = sentry_organization_code_mapping.this.id
+
+  raw = file("${path.module}/.github/CODEOWNERS")
+}
@@ -0,0 +1,3 @@
+# import using the organization and project slugs from the URL:
+# https://sentry.io/settings/[org-slug]/projects/[project-slug]/security-and-privacy/
+terraform import sentry_project_data_scrubbing.default org-slug/project-slug
@@ -0,0 +1,23 @@
+# Remove sensitive data from the events of a project
+resource "sentry_project_data_scrubbing" "default" {
+  organization = "my-organization"
+  project      = "web-app"
+
+  scrub_ip_addresses = true
+  sensitive_fields   = ["ssn", "api_token"]
+  safe_fields        = ["order_id"]
+
+  rule {
+    type   = "creditcard"
+    method = "mask"
+    source = "$message"
+  }
+
+  rule {
+    type        = "pattern"
+    method      = "replace"
+    source      = "$http.headers.x-auth-token"
+    pattern     = "[a-f0-9]{32}"
+    replacement = "[token]"
+  }
+}
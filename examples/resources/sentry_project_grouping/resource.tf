@@ -0,0 +1,19 @@
+# Tune how the events of a project are grouped into issues
+resource "sentry_project_grouping" "default" {
+  organization = "my-organization"
+  project      = "web-app"
+
+  fingerprinting_rules = <<-EOT
+    # Group all database outages together
+    error.type:DatabaseUnavailable -> system-down
+    logger:"api.*" tags.server_name:web-* -> {{ default }}, web title="API error"
+  EOT
+
+  stack_trace_rules = <<-EOT
+    stack.module:com.example.* +app
+    stack.function:panic_handler ^-group
+    family:native stack.function:std::* -app
+  EOT
+
+  grouping_config = "newstyle:2023-01-11"
+}
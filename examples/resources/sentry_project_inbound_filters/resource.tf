@@ -0,0 +1,25 @@
+# Filter out noise from a project
+resource "sentry_project_inbound_filters" "default" {
+  organization = "my-organization"
+  project      = "web-app"
+
+  browser_extensions {}
+  localhost {}
+  web_crawlers {}
+
+  legacy_browsers {
+    browsers = ["ie_pre_9", "ie9", "safari_pre_6"]
+  }
+
+  releases {
+    patterns = ["*-dev"]
+  }
+
+  error_messages {
+    patterns = ["*ResizeObserver loop limit exceeded*"]
+  }
+
+  blacklisted_ips {
+    addresses = ["10.0.0.0/8"]
+  }
+}
@@ -0,0 +1,3 @@
+# import using the organization and project slugs from the URL:
+# https://sentry.io/settings/[org-slug]/projects/[project-slug]/ownership/
+terraform import sentry_project_ownership.default org-slug/project-slug
@@ -0,0 +1,17 @@
+# Route issues of a project to its owners
+resource "sentry_project_ownership" "default" {
+  organization = "my-organization"
+  project      = "web-app"
+
+  raw = <<-EOT
+    # Backend
+    path:src/api/* #backend
+    module:com.example.billing.* #billing
+    url:*/checkout/* #payments jane@example.com
+    tags.browser:Chrome* #frontend
+  EOT
+
+  fallthrough          = false
+  auto_assignment      = "issue_owner"
+  codeowners_auto_sync = true
+}
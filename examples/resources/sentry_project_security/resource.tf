@@ -0,0 +1,22 @@
+# Restrict a browser project to its own domains
+resource "sentry_project_security" "default" {
+  organization = "my-organization"
+  project      = "web-app"
+
+  allowed_domains = ["https://example.com", "*.example.com"]
+
+  scrape_javascript     = true
+  security_token        = var.sentry_security_token
+  security_token_header = "X-Sentry-Token"
+  verify_ssl            = true
+
+  csp_ignored_sources_defaults = true
+  csp_ignored_sources          = ["https://ads.example.net"]
+
+  expect_ct_report_environment = "production"
+}
+
+# Send the Certificate Transparency failures of the site to Sentry
+output "expect_ct_header" {
+  value = "max-age=86400, report-uri=\"${sentry_project_security.default.expect_ct_report_uri}\""
+}
@@ -0,0 +1,3 @@
+# import using the organization and project slugs from the URL and the hook ID:
+# https://sentry.io/settings/[org-slug]/projects/[project-slug]/hooks/[hook-id]/
+terraform import sentry_project_service_hook.default org-slug/project-slug/hook-id
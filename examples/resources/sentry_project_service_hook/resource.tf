@@ -0,0 +1,8 @@
+# Forward the alerts of a project to an internal system
+resource "sentry_project_service_hook" "default" {
+  organization = "my-organization"
+  project      = "web-app"
+
+  url    = "https://example.com/hooks/sentry"
+  events = ["event.alert", "event.created"]
+}
@@ -0,0 +1,2 @@
+# import using the organization, project and team slugs:
+terraform import sentry_project_team.default org-slug/project-slug/team-slug
@@ -0,0 +1,17 @@
+# Create a project whose teams are managed elsewhere
+resource "sentry_project" "web_app" {
+  organization = "my-organization"
+
+  teams = ["my-first-team"]
+  name  = "Web App"
+  slug  = "web-app"
+
+  ignore_team_membership = true
+}
+
+# Give another team access to the project
+resource "sentry_project_team" "default" {
+  organization = "my-organization"
+  project      = sentry_project.web_app.id
+  team         = "my-second-team"
+}
@@ -12,7 +12,6 @@ require (
 	github.com/jianyuan/go-sentry/v2 v2.2.0
 	github.com/mitchellh/mapstructure v1.5.0
 	golang.org/x/oauth2 v0.5.0
-	golang.org/x/sync v0.1.0
 )
 
 require (
@@ -0,0 +1,128 @@
+package fakesentry
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+func (s *Server) registerDashboardRoutes() {
+	s.handle(http.MethodGet, "/api/0/organizations/{org}/dashboards/", s.listDashboards)
+	s.handle(http.MethodPost, "/api/0/organizations/{org}/dashboards/", s.createDashboard)
+	s.handle(http.MethodGet, "/api/0/organizations/{org}/dashboards/{dashboard}/", s.getDashboard)
+	s.handle(http.MethodPut, "/api/0/organizations/{org}/dashboards/{dashboard}/", s.updateDashboard)
+	s.handle(http.MethodDelete, "/api/0/organizations/{org}/dashboards/{dashboard}/", s.deleteDashboard)
+}
+
+// prepareDashboard validates the dashboard and assigns IDs to new widgets and
+// queries.
+func (s *Server) prepareDashboard(w http.ResponseWriter, dashboard *sentry.Dashboard) bool {
+	if sentry.StringValue(dashboard.Title) == "" {
+		writeValidationError(w, "title", "This field is required.")
+		return false
+	}
+
+	for _, widget := range dashboard.Widgets {
+		if widget.ID == nil {
+			widget.ID = sentry.String(s.newID())
+		}
+		if widget.WidgetType == nil {
+			widget.WidgetType = sentry.String("discover")
+		}
+		if widget.Interval == nil {
+			widget.Interval = sentry.String("5m")
+		}
+		if widget.Limit == nil {
+			widget.Limit = sentry.Int(0)
+		}
+		for _, query := range widget.Queries {
+			if query.ID == nil {
+				query.ID = sentry.String(s.newID())
+			}
+			if query.Fields == nil {
+				query.Fields = append(append([]string{}, query.Columns...), query.Aggregates...)
+			}
+		}
+	}
+	return true
+}
+
+func (s *Server) listDashboards(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	org := params["org"]
+	if _, ok := s.organizations[org]; !ok {
+		writeNotFound(w)
+		return
+	}
+
+	dashboards := []*sentry.Dashboard{}
+	for key, dashboard := range s.dashboards {
+		if orgSlug, _ := splitKey(key); orgSlug == org {
+			dashboards = append(dashboards, dashboard)
+		}
+	}
+	sort.Slice(dashboards, func(i, j int) bool {
+		a, _ := strconv.Atoi(*dashboards[i].ID)
+		b, _ := strconv.Atoi(*dashboards[j].ID)
+		return a < b
+	})
+	writeJSON(w, http.StatusOK, dashboards)
+}
+
+func (s *Server) createDashboard(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	org := params["org"]
+	if _, ok := s.organizations[org]; !ok {
+		writeNotFound(w)
+		return
+	}
+
+	dashboard := new(sentry.Dashboard)
+	if !decodeJSON(w, r, dashboard) || !s.prepareDashboard(w, dashboard) {
+		return
+	}
+	dashboard.ID = sentry.String(s.newID())
+	dashboard.DateCreated = sentry.Time(time.Now().UTC())
+
+	s.dashboards[joinKey(org, *dashboard.ID)] = dashboard
+	writeJSON(w, http.StatusCreated, dashboard)
+}
+
+func (s *Server) getDashboard(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	dashboard, ok := s.dashboards[joinKey(params["org"], params["dashboard"])]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, dashboard)
+}
+
+func (s *Server) updateDashboard(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	key := joinKey(params["org"], params["dashboard"])
+	existing, ok := s.dashboards[key]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	dashboard := new(sentry.Dashboard)
+	if !decodeJSON(w, r, dashboard) || !s.prepareDashboard(w, dashboard) {
+		return
+	}
+	dashboard.ID = existing.ID
+	dashboard.DateCreated = existing.DateCreated
+
+	s.dashboards[key] = dashboard
+	writeJSON(w, http.StatusOK, dashboard)
+}
+
+func (s *Server) deleteDashboard(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	key := joinKey(params["org"], params["dashboard"])
+	if _, ok := s.dashboards[key]; !ok {
+		writeNotFound(w)
+		return
+	}
+	delete(s.dashboards, key)
+	writeJSON(w, http.StatusNoContent, nil)
+}
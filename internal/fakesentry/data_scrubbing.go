@@ -0,0 +1,129 @@
+package fakesentry
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+// dataScrubbingParams are the data scrubbing settings accepted by the
+// project and organization update endpoints.
+type dataScrubbingParams struct {
+	DataScrubber         *bool     `json:"dataScrubber"`
+	DataScrubberDefaults *bool     `json:"dataScrubberDefaults"`
+	ScrubIPAddresses     *bool     `json:"scrubIPAddresses"`
+	SensitiveFields      *[]string `json:"sensitiveFields"`
+	SafeFields           *[]string `json:"safeFields"`
+	RelayPiiConfig       *string   `json:"relayPiiConfig"`
+}
+
+// validate responds with a validation error unless relayPiiConfig is empty
+// or a valid advanced data scrubbing configuration.
+func (params *dataScrubbingParams) validate(w http.ResponseWriter) bool {
+	if sentry.StringValue(params.RelayPiiConfig) == "" {
+		return true
+	}
+
+	var config struct {
+		Rules map[string]struct {
+			Type      string `json:"type"`
+			Pattern   string `json:"pattern"`
+			Redaction struct {
+				Method string `json:"method"`
+			} `json:"redaction"`
+		} `json:"rules"`
+		Applications map[string][]string `json:"applications"`
+	}
+	if err := json.Unmarshal([]byte(*params.RelayPiiConfig), &config); err != nil {
+		writeValidationError(w, "relayPiiConfig", "Invalid JSON: "+err.Error())
+		return false
+	}
+	for id, rule := range config.Rules {
+		if rule.Type == "" || rule.Redaction.Method == "" {
+			writeValidationError(w, "relayPiiConfig", "Rule "+id+" needs a type and a redaction method.")
+			return false
+		}
+		if rule.Type == "pattern" && rule.Pattern == "" {
+			writeValidationError(w, "relayPiiConfig", "Rule "+id+" needs a pattern.")
+			return false
+		}
+	}
+	for _, ids := range config.Applications {
+		for _, id := range ids {
+			if _, ok := config.Rules[id]; !ok {
+				writeValidationError(w, "relayPiiConfig", "Unknown rule "+id+".")
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// SetProjectRelayPiiConfig sets the advanced data scrubbing configuration of
+// a project, as if it had been changed in the UI.
+func (s *Server) SetProjectRelayPiiConfig(org, slug, config string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, ok := s.lookupProject(org, slug); ok {
+		p.relayPiiConfig = config
+	}
+}
+
+// ProjectRelayPiiConfig returns the advanced data scrubbing configuration of
+// a project.
+func (s *Server) ProjectRelayPiiConfig(org, slug string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, ok := s.lookupProject(org, slug); ok {
+		return p.relayPiiConfig
+	}
+	return ""
+}
+
+func (p *project) updateDataScrubbing(params *dataScrubbingParams) {
+	if params.DataScrubber != nil {
+		p.DataScrubber = *params.DataScrubber
+	}
+	if params.DataScrubberDefaults != nil {
+		p.DataScrubberDefaults = *params.DataScrubberDefaults
+	}
+	if params.ScrubIPAddresses != nil {
+		p.ScrubIPAddresses = *params.ScrubIPAddresses
+	}
+	if params.SensitiveFields != nil {
+		p.SensitiveFields = *params.SensitiveFields
+	}
+	if params.SafeFields != nil {
+		p.SafeFields = *params.SafeFields
+	}
+	if params.RelayPiiConfig != nil {
+		p.relayPiiConfig = *params.RelayPiiConfig
+	}
+}
+
+func updateOrganizationDataScrubbing(org *sentry.Organization, params *dataScrubbingParams) {
+	if params.DataScrubber != nil {
+		org.DataScrubber = params.DataScrubber
+	}
+	if params.DataScrubberDefaults != nil {
+		org.DataScrubberDefaults = params.DataScrubberDefaults
+	}
+	if params.ScrubIPAddresses != nil {
+		org.ScrubIPAddresses = params.ScrubIPAddresses
+	}
+	if params.SensitiveFields != nil {
+		org.SensitiveFields = *params.SensitiveFields
+	}
+	if params.SafeFields != nil {
+		org.SafeFields = *params.SafeFields
+	}
+	if params.RelayPiiConfig != nil {
+		org.RelayPiiConfig = nil
+		if *params.RelayPiiConfig != "" {
+			org.RelayPiiConfig = params.RelayPiiConfig
+		}
+	}
+}
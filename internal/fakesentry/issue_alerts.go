@@ -0,0 +1,195 @@
+package fakesentry
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+func (s *Server) registerIssueAlertRoutes() {
+	s.handle(http.MethodGet, "/api/0/projects/{org}/{project}/rules/", s.listIssueAlerts)
+	s.handle(http.MethodPost, "/api/0/projects/{org}/{project}/rules/", s.createIssueAlert)
+	s.handle(http.MethodGet, "/api/0/projects/{org}/{project}/rules/{rule}/", s.getIssueAlert)
+	s.handle(http.MethodPut, "/api/0/projects/{org}/{project}/rules/{rule}/", s.updateIssueAlert)
+	s.handle(http.MethodDelete, "/api/0/projects/{org}/{project}/rules/{rule}/", s.deleteIssueAlert)
+}
+
+func (p *project) lookupIssueAlert(id string) (*sentry.IssueAlert, int) {
+	for i, alert := range p.issueAlerts {
+		if sentry.StringValue(alert.ID) == id {
+			return alert, i
+		}
+	}
+	return nil, -1
+}
+
+// validateIssueAlert mirrors the required fields enforced by Sentry's rule
+// serializer and fills in the display names the server adds to each
+// condition, filter and action.
+func validateIssueAlert(w http.ResponseWriter, alert *sentry.IssueAlert) bool {
+	switch {
+	case sentry.StringValue(alert.Name) == "":
+		writeValidationError(w, "name", "This field is required.")
+		return false
+	case sentry.StringValue(alert.ActionMatch) == "":
+		writeValidationError(w, "actionMatch", "This field is required.")
+		return false
+	case alert.Frequency == nil:
+		writeValidationError(w, "frequency", "This field is required.")
+		return false
+	case len(alert.Actions) == 0:
+		writeValidationError(w, "actions", "You must add an action for this alert to fire.")
+		return false
+	}
+
+	for _, c := range alert.Conditions {
+		if _, ok := (*c)["name"]; !ok {
+			(*c)["name"] = (*c)["id"]
+		}
+	}
+	for _, f := range alert.Filters {
+		if _, ok := (*f)["name"]; !ok {
+			(*f)["name"] = (*f)["id"]
+		}
+	}
+	for _, a := range alert.Actions {
+		if _, ok := (*a)["name"]; !ok {
+			(*a)["name"] = (*a)["id"]
+		}
+	}
+	return true
+}
+
+func (s *Server) listIssueAlerts(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	alerts := []*sentry.IssueAlert{}
+	alerts = append(alerts, p.issueAlerts...)
+	writeJSON(w, http.StatusOK, alerts)
+}
+
+func (s *Server) createIssueAlert(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	alert := new(sentry.IssueAlert)
+	if !decodeJSON(w, r, alert) || !validateIssueAlert(w, alert) {
+		return
+	}
+	if alert.FilterMatch == nil {
+		alert.FilterMatch = sentry.String("all")
+	}
+	alert.ID = sentry.String(s.newID())
+	alert.DateCreated = sentry.Time(time.Now().UTC())
+	alert.Projects = []string{p.Slug}
+	alert.TaskUUID = nil
+
+	p.issueAlerts = append(p.issueAlerts, alert)
+	writeJSON(w, http.StatusCreated, alert)
+}
+
+func (s *Server) getIssueAlert(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	alert, _ := p.lookupIssueAlert(params["rule"])
+	if alert == nil {
+		writeNotFound(w)
+		return
+	}
+	alert.Projects = []string{p.Slug}
+	writeJSON(w, http.StatusOK, alert)
+}
+
+func (s *Server) updateIssueAlert(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	existing, i := p.lookupIssueAlert(params["rule"])
+	if existing == nil {
+		writeNotFound(w)
+		return
+	}
+
+	alert := new(sentry.IssueAlert)
+	if !decodeJSON(w, r, alert) || !validateIssueAlert(w, alert) {
+		return
+	}
+	if alert.FilterMatch == nil {
+		alert.FilterMatch = sentry.String("all")
+	}
+	alert.ID = existing.ID
+	alert.DateCreated = existing.DateCreated
+	alert.Projects = []string{p.Slug}
+	alert.TaskUUID = nil
+
+	p.issueAlerts[i] = alert
+	writeJSON(w, http.StatusOK, alert)
+}
+
+func (s *Server) deleteIssueAlert(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	_, i := p.lookupIssueAlert(params["rule"])
+	if i < 0 {
+		writeNotFound(w)
+		return
+	}
+	p.issueAlerts = append(p.issueAlerts[:i], p.issueAlerts[i+1:]...)
+	writeJSON(w, http.StatusAccepted, nil)
+}
+
+// newDefaultIssueAlert returns the issue alert Sentry creates with a project
+// unless asked not to.
+func (s *Server) newDefaultIssueAlert(p *project) *sentry.IssueAlert {
+	return &sentry.IssueAlert{
+		ID:          sentry.String(s.newID()),
+		Name:        sentry.String("Send a notification for new issues"),
+		ActionMatch: sentry.String("all"),
+		FilterMatch: sentry.String("all"),
+		Frequency:   sentry.Int(30),
+		Conditions: []*sentry.IssueAlertCondition{
+			{"id": "sentry.rules.conditions.first_seen_event.FirstSeenEventCondition"},
+		},
+		Actions: []*sentry.IssueAlertAction{
+			{
+				"id":              "sentry.mail.actions.NotifyEmailAction",
+				"targetType":      "IssueOwners",
+				"fallthroughType": "ActiveMembers",
+			},
+		},
+		DateCreated: sentry.Time(time.Now().UTC()),
+		Projects:    []string{p.Slug},
+	}
+}
+
+// IssueAlertIDs returns the IDs of the issue alerts of a project.
+func (s *Server) IssueAlertIDs(org, slug string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.lookupProject(org, slug)
+	if !ok {
+		return nil
+	}
+	ids := make([]string, 0, len(p.issueAlerts))
+	for _, alert := range p.issueAlerts {
+		ids = append(ids, sentry.StringValue(alert.ID))
+	}
+	return ids
+}
@@ -0,0 +1,191 @@
+package fakesentry
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+func (s *Server) registerMetricAlertRoutes() {
+	s.handle(http.MethodGet, "/api/0/projects/{org}/{project}/alert-rules/", s.listMetricAlerts)
+	s.handle(http.MethodPost, "/api/0/projects/{org}/{project}/alert-rules/", s.createMetricAlert)
+	s.handle(http.MethodGet, "/api/0/organizations/{org}/alert-rules/{rule}/", s.getMetricAlert)
+	s.handle(http.MethodPut, "/api/0/projects/{org}/{project}/alert-rules/{rule}/", s.updateMetricAlert)
+	s.handle(http.MethodDelete, "/api/0/projects/{org}/{project}/alert-rules/{rule}/", s.deleteMetricAlert)
+}
+
+func (p *project) lookupMetricAlert(id string) (*sentry.MetricAlert, int) {
+	for i, alert := range p.metricAlerts {
+		if sentry.StringValue(alert.ID) == id {
+			return alert, i
+		}
+	}
+	return nil, -1
+}
+
+// lookupOrganizationMetricAlert finds a metric alert by ID across every project
+// of the organization, like the organization-scoped alert rule endpoint does.
+func (s *Server) lookupOrganizationMetricAlert(org, id string) (*project, *sentry.MetricAlert) {
+	for _, p := range s.sortedProjects() {
+		if sentry.StringValue(p.Organization.Slug) != org {
+			continue
+		}
+		if alert, _ := p.lookupMetricAlert(id); alert != nil {
+			return p, alert
+		}
+	}
+	return nil, nil
+}
+
+func validateMetricAlert(w http.ResponseWriter, alert *sentry.MetricAlert) bool {
+	switch {
+	case sentry.StringValue(alert.Name) == "":
+		writeValidationError(w, "name", "This field is required.")
+		return false
+	case sentry.StringValue(alert.Aggregate) == "":
+		writeValidationError(w, "aggregate", "This field is required.")
+		return false
+	case alert.TimeWindow == nil:
+		writeValidationError(w, "timeWindow", "This field is required.")
+		return false
+	case len(alert.Triggers) == 0:
+		writeValidationError(w, "nonFieldErrors", "Must include at least one trigger")
+		return false
+	}
+
+	// Errors of triggers are aligned with the list of triggers.
+	triggerErrors := make([]map[string][]string, len(alert.Triggers))
+	valid := true
+	for i, trigger := range alert.Triggers {
+		triggerErrors[i] = map[string][]string{}
+		if label := sentry.StringValue(trigger.Label); label != "critical" && label != "warning" {
+			triggerErrors[i]["label"] = []string{"Invalid trigger label, must be one of critical, warning"}
+			valid = false
+		}
+		if trigger.AlertThreshold == nil {
+			triggerErrors[i]["alertThreshold"] = []string{"This field is required."}
+			valid = false
+		}
+	}
+	if !valid {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{"triggers": triggerErrors})
+	}
+	return valid
+}
+
+// assignMetricAlertIDs gives new triggers and actions an ID while keeping the
+// IDs of the ones that were sent back by the client.
+func (s *Server) assignMetricAlertIDs(alert *sentry.MetricAlert) {
+	now := time.Now().UTC()
+	if alert.DataSet == nil || *alert.DataSet == "" {
+		alert.DataSet = sentry.String("events")
+	}
+	if alert.Query == nil {
+		alert.Query = sentry.String("")
+	}
+	for _, trigger := range alert.Triggers {
+		if trigger.ID == nil {
+			trigger.ID = sentry.String(s.newID())
+			trigger.DateCreated = sentry.Time(now)
+		}
+		trigger.AlertRuleID = alert.ID
+		if trigger.ResolveThreshold == nil {
+			trigger.ResolveThreshold = alert.ResolveThreshold
+		}
+		if trigger.Actions == nil {
+			trigger.Actions = []*sentry.MetricAlertTriggerAction{}
+		}
+		for _, action := range trigger.Actions {
+			if action.ID == nil {
+				action.ID = sentry.String(s.newID())
+				action.DateCreated = sentry.Time(now)
+			}
+			action.AlertRuleTriggerID = trigger.ID
+		}
+	}
+}
+
+func (s *Server) listMetricAlerts(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	alerts := []*sentry.MetricAlert{}
+	alerts = append(alerts, p.metricAlerts...)
+	writeJSON(w, http.StatusOK, alerts)
+}
+
+func (s *Server) createMetricAlert(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	alert := new(sentry.MetricAlert)
+	if !decodeJSON(w, r, alert) || !validateMetricAlert(w, alert) {
+		return
+	}
+	alert.ID = sentry.String(s.newID())
+	alert.DateCreated = sentry.Time(time.Now().UTC())
+	alert.Projects = []string{p.Slug}
+	alert.TaskUUID = nil
+	s.assignMetricAlertIDs(alert)
+
+	p.metricAlerts = append(p.metricAlerts, alert)
+	writeJSON(w, http.StatusCreated, alert)
+}
+
+func (s *Server) getMetricAlert(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, alert := s.lookupOrganizationMetricAlert(params["org"], params["rule"])
+	if alert == nil {
+		writeNotFound(w)
+		return
+	}
+	alert.Projects = []string{p.Slug}
+	writeJSON(w, http.StatusOK, alert)
+}
+
+func (s *Server) updateMetricAlert(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	existing, i := p.lookupMetricAlert(params["rule"])
+	if existing == nil {
+		writeNotFound(w)
+		return
+	}
+
+	alert := new(sentry.MetricAlert)
+	if !decodeJSON(w, r, alert) || !validateMetricAlert(w, alert) {
+		return
+	}
+	alert.ID = existing.ID
+	alert.DateCreated = existing.DateCreated
+	alert.Projects = []string{p.Slug}
+	alert.TaskUUID = nil
+	s.assignMetricAlertIDs(alert)
+
+	p.metricAlerts[i] = alert
+	writeJSON(w, http.StatusOK, alert)
+}
+
+func (s *Server) deleteMetricAlert(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	_, i := p.lookupMetricAlert(params["rule"])
+	if i < 0 {
+		writeNotFound(w)
+		return
+	}
+	p.metricAlerts = append(p.metricAlerts[:i], p.metricAlerts[i+1:]...)
+	writeJSON(w, http.StatusNoContent, nil)
+}
@@ -0,0 +1,141 @@
+package fakesentry
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+func (s *Server) registerOrganizationCodeMappingRoutes() {
+	s.handle(http.MethodGet, "/api/0/organizations/{org}/code-mappings/", s.listOrganizationCodeMappings)
+	s.handle(http.MethodPost, "/api/0/organizations/{org}/code-mappings/", s.createOrganizationCodeMapping)
+	s.handle(http.MethodPut, "/api/0/organizations/{org}/code-mappings/{mapping}/", s.updateOrganizationCodeMapping)
+	s.handle(http.MethodDelete, "/api/0/organizations/{org}/code-mappings/{mapping}/", s.deleteOrganizationCodeMapping)
+}
+
+// applyCodeMapping validates the parameters against the organization's
+// projects and repositories and copies them onto the code mapping.
+func (s *Server) applyCodeMapping(w http.ResponseWriter, org string, params sentry.CreateOrganizationCodeMappingParams, mapping *sentry.OrganizationCodeMapping) bool {
+	p, ok := s.projects[params.ProjectId]
+	if !ok || sentry.StringValue(p.Organization.Slug) != org {
+		writeValidationError(w, "project", "Project does not exist")
+		return false
+	}
+	repo, ok := s.repositories[joinKey(org, params.RepositoryId)]
+	if !ok {
+		writeValidationError(w, "repositoryId", "Repository does not exist")
+		return false
+	}
+	if params.DefaultBranch == "" {
+		writeValidationError(w, "defaultBranch", "This field is required.")
+		return false
+	}
+
+	mapping.ProjectId = p.ID
+	mapping.ProjectSlug = p.Slug
+	mapping.RepoId = repo.ID
+	mapping.RepoName = repo.Name
+	mapping.IntegrationId = params.IntegrationId
+	mapping.StackRoot = params.StackRoot
+	mapping.SourceRoot = params.SourceRoot
+	mapping.DefaultBranch = params.DefaultBranch
+	return true
+}
+
+func (s *Server) listOrganizationCodeMappings(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	org := params["org"]
+	if _, ok := s.organizations[org]; !ok {
+		writeNotFound(w)
+		return
+	}
+
+	integrationID := r.URL.Query().Get("integrationId")
+
+	mappings := []*sentry.OrganizationCodeMapping{}
+	for key, mapping := range s.codeMappings {
+		if orgSlug, _ := splitKey(key); orgSlug != org {
+			continue
+		}
+		if integrationID != "" && mapping.IntegrationId != integrationID {
+			continue
+		}
+		mappings = append(mappings, mapping)
+	}
+	sort.Slice(mappings, func(i, j int) bool {
+		a, _ := strconv.Atoi(mappings[i].ID)
+		b, _ := strconv.Atoi(mappings[j].ID)
+		return a < b
+	})
+	writeJSON(w, http.StatusOK, mappings)
+}
+
+func (s *Server) createOrganizationCodeMapping(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	org := params["org"]
+	if _, ok := s.organizations[org]; !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var body sentry.CreateOrganizationCodeMappingParams
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+
+	mapping := new(sentry.OrganizationCodeMapping)
+	if !s.applyCodeMapping(w, org, body, mapping) {
+		return
+	}
+	mapping.ID = s.newID()
+	s.codeMappings[joinKey(org, mapping.ID)] = mapping
+	writeJSON(w, http.StatusCreated, mapping)
+}
+
+func (s *Server) updateOrganizationCodeMapping(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	org := params["org"]
+	mapping, ok := s.codeMappings[joinKey(org, params["mapping"])]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var body sentry.CreateOrganizationCodeMappingParams
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+	if !s.applyCodeMapping(w, org, body, mapping) {
+		return
+	}
+	writeJSON(w, http.StatusOK, mapping)
+}
+
+func (s *Server) deleteOrganizationCodeMapping(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	key := joinKey(params["org"], params["mapping"])
+	if _, ok := s.codeMappings[key]; !ok {
+		writeNotFound(w)
+		return
+	}
+	delete(s.codeMappings, key)
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+// AddCodeMapping seeds a code mapping of the given project, without a
+// repository, and returns its ID.
+func (s *Server) AddCodeMapping(org, project string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.lookupProject(org, project)
+	if !ok {
+		return ""
+	}
+	mapping := &sentry.OrganizationCodeMapping{
+		ID:            s.newID(),
+		ProjectId:     p.ID,
+		ProjectSlug:   p.Slug,
+		DefaultBranch: "main",
+	}
+	s.codeMappings[joinKey(org, mapping.ID)] = mapping
+	return mapping.ID
+}
@@ -0,0 +1,62 @@
+package fakesentry
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+// AddIntegration seeds an installed integration in the given organization and
+// returns its ID. Integrations can only be installed through the Sentry UI, so
+// there is no endpoint to create them.
+func (s *Server) AddIntegration(org, providerKey, name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	integration := &sentry.OrganizationIntegration{
+		ID:     s.newID(),
+		Name:   name,
+		Status: "active",
+		Provider: sentry.OrganizationIntegrationProvider{
+			Key:  providerKey,
+			Slug: providerKey,
+			Name: providerKey,
+		},
+		OrganizationIntegrationStatus: "active",
+	}
+	s.integrations[joinKey(org, integration.ID)] = integration
+	return integration.ID
+}
+
+func (s *Server) registerOrganizationIntegrationRoutes() {
+	s.handle(http.MethodGet, "/api/0/organizations/{org}/integrations/", s.listOrganizationIntegrations)
+}
+
+func (s *Server) listOrganizationIntegrations(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	org := params["org"]
+	if _, ok := s.organizations[org]; !ok {
+		writeNotFound(w)
+		return
+	}
+
+	providerKey := r.URL.Query().Get("provider_key")
+
+	integrations := []*sentry.OrganizationIntegration{}
+	for key, integration := range s.integrations {
+		if orgSlug, _ := splitKey(key); orgSlug != org {
+			continue
+		}
+		if providerKey != "" && integration.Provider.Key != providerKey {
+			continue
+		}
+		integrations = append(integrations, integration)
+	}
+	sort.Slice(integrations, func(i, j int) bool {
+		a, _ := strconv.Atoi(integrations[i].ID)
+		b, _ := strconv.Atoi(integrations[j].ID)
+		return a < b
+	})
+	writeJSON(w, http.StatusOK, integrations)
+}
@@ -0,0 +1,95 @@
+package fakesentry
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+func (s *Server) registerOrganizationRepositoryRoutes() {
+	s.handle(http.MethodGet, "/api/0/organizations/{org}/repos/", s.listOrganizationRepositories)
+	s.handle(http.MethodPost, "/api/0/organizations/{org}/repos/", s.createOrganizationRepository)
+	s.handle(http.MethodDelete, "/api/0/organizations/{org}/repos/{repo}/", s.deleteOrganizationRepository)
+}
+
+func (s *Server) listOrganizationRepositories(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	org := params["org"]
+	if _, ok := s.organizations[org]; !ok {
+		writeNotFound(w)
+		return
+	}
+
+	// The query does a fuzzy match on the repository name.
+	query := r.URL.Query().Get("query")
+
+	repos := []*sentry.OrganizationRepository{}
+	for key, repo := range s.repositories {
+		if orgSlug, _ := splitKey(key); orgSlug == org && strings.Contains(repo.Name, query) {
+			repos = append(repos, repo)
+		}
+	}
+	sort.Slice(repos, func(i, j int) bool {
+		a, _ := strconv.Atoi(repos[i].ID)
+		b, _ := strconv.Atoi(repos[j].ID)
+		return a < b
+	})
+	writeJSON(w, http.StatusOK, repos)
+}
+
+func (s *Server) createOrganizationRepository(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	org := params["org"]
+	if _, ok := s.organizations[org]; !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var body map[string]interface{}
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+	identifier, _ := body["identifier"].(string)
+	if identifier == "" {
+		writeValidationError(w, "identifier", "This field is required.")
+		return
+	}
+	for key, repo := range s.repositories {
+		if orgSlug, _ := splitKey(key); orgSlug == org && repo.Name == identifier {
+			writeDetail(w, http.StatusBadRequest, "A repository with that name already exists")
+			return
+		}
+	}
+
+	provider, _ := body["provider"].(string)
+	repo := &sentry.OrganizationRepository{
+		ID:   s.newID(),
+		Name: identifier,
+		Url:  "https://github.com/" + identifier,
+		Provider: sentry.OrganizationRepositoryProvider{
+			ID:   provider,
+			Name: strings.TrimPrefix(provider, "integrations:"),
+		},
+		Status:        "active",
+		DateCreated:   time.Now().UTC(),
+		IntegrationId: fmt.Sprint(body["installation"]),
+		ExternalSlug:  identifier,
+	}
+	s.repositories[joinKey(org, repo.ID)] = repo
+	writeJSON(w, http.StatusCreated, repo)
+}
+
+func (s *Server) deleteOrganizationRepository(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	key := joinKey(params["org"], params["repo"])
+	repo, ok := s.repositories[key]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	delete(s.repositories, key)
+	repo.Status = "pending_deletion"
+	writeJSON(w, http.StatusAccepted, repo)
+}
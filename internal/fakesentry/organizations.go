@@ -0,0 +1,158 @@
+package fakesentry
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+// AddOrganization seeds an organization and returns its slug.
+func (s *Server) AddOrganization(slug, name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.organizations[slug] = &sentry.Organization{
+		ID:                   sentry.String(s.newID()),
+		Slug:                 sentry.String(slug),
+		Name:                 sentry.String(name),
+		DateCreated:          sentry.Time(time.Now().UTC()),
+		DataScrubber:         sentry.Bool(true),
+		DataScrubberDefaults: sentry.Bool(true),
+		ScrubIPAddresses:     sentry.Bool(false),
+	}
+	return slug
+}
+
+func (s *Server) registerOrganizationRoutes() {
+	s.handle(http.MethodGet, "/api/0/organizations/", s.listOrganizations)
+	s.handle(http.MethodPost, "/api/0/organizations/", s.createOrganization)
+	s.handle(http.MethodGet, "/api/0/organizations/{org}/", s.getOrganization)
+	s.handle(http.MethodPut, "/api/0/organizations/{org}/", s.updateOrganization)
+	s.handle(http.MethodDelete, "/api/0/organizations/{org}/", s.deleteOrganization)
+}
+
+func (s *Server) listOrganizations(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	orgs := make([]*sentry.Organization, 0, len(s.organizations))
+	for _, org := range s.organizations {
+		orgs = append(orgs, org)
+	}
+	sort.Slice(orgs, func(i, j int) bool {
+		return *orgs[i].Slug < *orgs[j].Slug
+	})
+	writeJSON(w, http.StatusOK, orgs)
+}
+
+func (s *Server) createOrganization(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	var body sentry.CreateOrganizationParams
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+	if sentry.StringValue(body.Name) == "" {
+		writeValidationError(w, "name", "This field is required.")
+		return
+	}
+	if !sentry.BoolValue(body.AgreeTerms) {
+		writeValidationError(w, "agreeTerms", "This attribute is required.")
+		return
+	}
+
+	slug := sentry.StringValue(body.Slug)
+	if slug == "" {
+		slug = slugify(*body.Name)
+	}
+	if _, ok := s.organizations[slug]; ok {
+		writeDetail(w, http.StatusConflict, "An organization with this slug already exists.")
+		return
+	}
+
+	org := &sentry.Organization{
+		ID:                   sentry.String(s.newID()),
+		Slug:                 sentry.String(slug),
+		Name:                 body.Name,
+		DateCreated:          sentry.Time(time.Now().UTC()),
+		DataScrubber:         sentry.Bool(true),
+		DataScrubberDefaults: sentry.Bool(true),
+		ScrubIPAddresses:     sentry.Bool(false),
+	}
+	s.organizations[slug] = org
+	writeJSON(w, http.StatusCreated, org)
+}
+
+func (s *Server) getOrganization(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	org, ok := s.organizations[params["org"]]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, org)
+}
+
+func (s *Server) updateOrganization(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	org, ok := s.organizations[params["org"]]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var body struct {
+		sentry.UpdateOrganizationParams
+		dataScrubbingParams
+	}
+	if !decodeJSON(w, r, &body) || !body.validate(w) {
+		return
+	}
+	if body.Name != nil {
+		org.Name = body.Name
+	}
+	if body.Slug != nil && *body.Slug != *org.Slug {
+		if _, ok := s.organizations[*body.Slug]; ok {
+			writeValidationError(w, "slug", "The slug \""+*body.Slug+"\" is already in use.")
+			return
+		}
+		s.renameOrganization(*org.Slug, *body.Slug)
+	}
+	updateOrganizationDataScrubbing(org, &body.dataScrubbingParams)
+	writeJSON(w, http.StatusOK, org)
+}
+
+func (s *Server) deleteOrganization(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	slug := params["org"]
+	if _, ok := s.organizations[slug]; !ok {
+		writeNotFound(w)
+		return
+	}
+
+	delete(s.organizations, slug)
+	for id, p := range s.projects {
+		if sentry.StringValue(p.Organization.Slug) == slug {
+			delete(s.projects, id)
+		}
+	}
+	for key := range s.teams {
+		if orgSlug, _ := splitKey(key); orgSlug == slug {
+			delete(s.teams, key)
+		}
+	}
+	writeJSON(w, http.StatusAccepted, nil)
+}
+
+func (s *Server) renameOrganization(from, to string) {
+	org := s.organizations[from]
+	delete(s.organizations, from)
+	org.Slug = sentry.String(to)
+	s.organizations[to] = org
+
+	for key, team := range s.teams {
+		if orgSlug, teamSlug := splitKey(key); orgSlug == from {
+			delete(s.teams, key)
+			s.teams[joinKey(to, teamSlug)] = team
+		}
+	}
+	for _, p := range s.projects {
+		if sentry.StringValue(p.Organization.Slug) == from {
+			p.Organization = *org
+		}
+	}
+}
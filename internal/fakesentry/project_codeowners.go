@@ -0,0 +1,170 @@
+package fakesentry
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+type codeowners struct {
+	ID            string              `json:"id"`
+	Raw           string              `json:"raw"`
+	CodeMappingID string              `json:"codeMappingId"`
+	Provider      string              `json:"provider"`
+	DateCreated   time.Time           `json:"dateCreated"`
+	DateUpdated   time.Time           `json:"dateUpdated"`
+	Errors        map[string][]string `json:"errors"`
+}
+
+func (s *Server) registerProjectCodeownersRoutes() {
+	s.handle(http.MethodGet, "/api/0/projects/{org}/{project}/codeowners/", s.listProjectCodeowners)
+	s.handle(http.MethodPost, "/api/0/projects/{org}/{project}/codeowners/", s.createProjectCodeowners)
+	s.handle(http.MethodPut, "/api/0/projects/{org}/{project}/codeowners/{codeowners}/", s.updateProjectCodeowners)
+	s.handle(http.MethodDelete, "/api/0/projects/{org}/{project}/codeowners/{codeowners}/", s.deleteProjectCodeowners)
+}
+
+type codeownersParams struct {
+	Raw           *string `json:"raw"`
+	CodeMappingID *string `json:"codeMappingId"`
+}
+
+// applyCodeowners validates the parameters and copies them onto c. The fake
+// server has no users nor external associations, so every owner of the raw
+// CODEOWNERS content is reported as missing.
+func (s *Server) applyCodeowners(w http.ResponseWriter, org string, p *project, params codeownersParams, c *codeowners) bool {
+	if params.Raw == nil || strings.TrimSpace(*params.Raw) == "" {
+		writeValidationError(w, "raw", "This field is required.")
+		return false
+	}
+	if params.CodeMappingID == nil {
+		writeValidationError(w, "codeMappingId", "This field is required.")
+		return false
+	}
+	mapping, ok := s.codeMappings[joinKey(org, *params.CodeMappingID)]
+	if !ok || mapping.ProjectId != p.ID {
+		writeValidationError(w, "codeMappingId", "This code mapping does not exist.")
+		return false
+	}
+	for _, other := range p.codeowners {
+		if other != c && other.CodeMappingID == *params.CodeMappingID {
+			writeValidationError(w, "codeMappingId", "This code mapping is already in use.")
+			return false
+		}
+	}
+
+	c.Raw = *params.Raw
+	c.CodeMappingID = *params.CodeMappingID
+	c.Provider = "github"
+	c.Errors = map[string][]string{
+		"missing_user_emails":    {},
+		"missing_external_users": {},
+		"missing_external_teams": {},
+		"teams_without_access":   {},
+		"users_without_access":   {},
+	}
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(c.Raw, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		for _, owner := range fields[1:] {
+			if seen[owner] {
+				continue
+			}
+			seen[owner] = true
+			switch {
+			case strings.HasPrefix(owner, "@") && strings.Contains(owner, "/"):
+				c.Errors["missing_external_teams"] = append(c.Errors["missing_external_teams"], owner)
+			case strings.HasPrefix(owner, "@"):
+				c.Errors["missing_external_users"] = append(c.Errors["missing_external_users"], owner)
+			default:
+				c.Errors["missing_user_emails"] = append(c.Errors["missing_user_emails"], owner)
+			}
+		}
+	}
+	c.DateUpdated = time.Now().UTC()
+	return true
+}
+
+func (s *Server) listProjectCodeowners(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	list := p.codeowners
+	if list == nil {
+		list = []*codeowners{}
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (s *Server) createProjectCodeowners(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var body codeownersParams
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+	c := new(codeowners)
+	if !s.applyCodeowners(w, params["org"], p, body, c) {
+		return
+	}
+	c.ID = s.newID()
+	c.DateCreated = c.DateUpdated
+	p.codeowners = append(p.codeowners, c)
+	writeJSON(w, http.StatusCreated, c)
+}
+
+func (s *Server) lookupCodeowners(p *project, id string) (int, bool) {
+	for i, c := range p.codeowners {
+		if c.ID == id {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func (s *Server) updateProjectCodeowners(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	i, ok := s.lookupCodeowners(p, params["codeowners"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	c := p.codeowners[i]
+	raw, mappingID := c.Raw, c.CodeMappingID
+	body := codeownersParams{Raw: &raw, CodeMappingID: &mappingID}
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+	if !s.applyCodeowners(w, params["org"], p, body, c) {
+		return
+	}
+	writeJSON(w, http.StatusOK, c)
+}
+
+func (s *Server) deleteProjectCodeowners(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	i, ok := s.lookupCodeowners(p, params["codeowners"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	p.codeowners = append(p.codeowners[:i], p.codeowners[i+1:]...)
+	writeJSON(w, http.StatusNoContent, nil)
+}
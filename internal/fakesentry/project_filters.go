@@ -0,0 +1,112 @@
+package fakesentry
+
+import (
+	"net/http"
+)
+
+// projectFilterIDs are the inbound filters of every project, in the order
+// Sentry lists them.
+var projectFilterIDs = []string{
+	"browser-extensions",
+	"localhost",
+	"legacy-browsers",
+	"web-crawlers",
+}
+
+func newProjectFilters() map[string]interface{} {
+	filters := make(map[string]interface{}, len(projectFilterIDs))
+	for _, id := range projectFilterIDs {
+		filters[id] = false
+	}
+	return filters
+}
+
+func (s *Server) registerProjectFilterRoutes() {
+	s.handle(http.MethodGet, "/api/0/projects/{org}/{project}/filters/", s.listProjectFilters)
+	s.handle(http.MethodPut, "/api/0/projects/{org}/{project}/filters/{filter}/", s.updateProjectFilter)
+}
+
+func (s *Server) listProjectFilters(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	filters := make([]map[string]interface{}, 0, len(projectFilterIDs))
+	for _, id := range projectFilterIDs {
+		filters = append(filters, map[string]interface{}{
+			"id":     id,
+			"active": p.filters[id],
+		})
+	}
+	writeJSON(w, http.StatusOK, filters)
+}
+
+func (s *Server) updateProjectFilter(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	id := params["filter"]
+	if _, ok := p.filters[id]; !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var body struct {
+		Active     *bool     `json:"active"`
+		Subfilters *[]string `json:"subfilters"`
+	}
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+
+	switch {
+	case id == "legacy-browsers" && body.Subfilters == nil:
+		// Without subfilters, the filter is either off or on for all the
+		// legacy browsers.
+		if body.Active == nil {
+			writeValidationError(w, "subfilters", "This field is required.")
+			return
+		}
+		p.filters[id] = *body.Active
+	case id == "legacy-browsers":
+		if len(*body.Subfilters) == 0 {
+			p.filters[id] = false
+		} else {
+			p.filters[id] = *body.Subfilters
+		}
+	case body.Active == nil:
+		writeValidationError(w, "active", "This field is required.")
+		return
+	default:
+		p.filters[id] = *body.Active
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetProjectFilter sets the state of an inbound filter of a project, as if it
+// had been changed in the UI: a bool, or the subfilters of legacy-browsers,
+// for which true means all of them.
+func (s *Server) SetProjectFilter(org, slug, id string, active interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, ok := s.lookupProject(org, slug); ok {
+		p.filters[id] = active
+	}
+}
+
+// ProjectFilter returns the state of an inbound filter of a project.
+func (s *Server) ProjectFilter(org, slug, id string) interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, ok := s.lookupProject(org, slug); ok {
+		return p.filters[id]
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package fakesentry
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+// browserSdkVersions are the browser SDK versions the JavaScript Loader
+// Script can load.
+var browserSdkVersions = map[string]bool{
+	"latest": true,
+	"7.x":    true,
+	"8.x":    true,
+}
+
+type projectKey struct {
+	*sentry.ProjectKey
+	BrowserSdkVersion       string              `json:"browserSdkVersion"`
+	DynamicSdkLoaderOptions loaderScriptOptions `json:"dynamicSdkLoaderOptions"`
+}
+
+type loaderScriptOptions struct {
+	HasReplay      bool `json:"hasReplay"`
+	HasPerformance bool `json:"hasPerformance"`
+	HasDebug       bool `json:"hasDebug"`
+}
+
+func (s *Server) registerProjectKeyRoutes() {
+	s.handle(http.MethodGet, "/api/0/projects/{org}/{project}/keys/", s.listProjectKeys)
+	s.handle(http.MethodPost, "/api/0/projects/{org}/{project}/keys/", s.createProjectKey)
+	s.handle(http.MethodGet, "/api/0/projects/{org}/{project}/keys/{key}/", s.getProjectKey)
+	s.handle(http.MethodPut, "/api/0/projects/{org}/{project}/keys/{key}/", s.updateProjectKey)
+	s.handle(http.MethodDelete, "/api/0/projects/{org}/{project}/keys/{key}/", s.deleteProjectKey)
+}
+
+func (p *project) lookupKey(id string) (*projectKey, int) {
+	for i, key := range p.keys {
+		if key.ID == id {
+			return key, i
+		}
+	}
+	return nil, -1
+}
+
+func (s *Server) newProjectKey(p *project, name string, rateLimit *sentry.ProjectKeyRateLimit) *projectKey {
+	host := s.URL
+	if u, err := url.Parse(s.URL); err == nil {
+		host = u.Host
+	}
+
+	key := &sentry.ProjectKey{
+		ID:          randomHex(16),
+		Name:        name,
+		Label:       name,
+		Public:      randomHex(16),
+		Secret:      randomHex(16),
+		IsActive:    true,
+		DateCreated: time.Now().UTC(),
+	}
+	fmt.Sscan(p.ID, &key.ProjectID)
+	if rateLimit != nil && (rateLimit.Window != 0 || rateLimit.Count != 0) {
+		key.RateLimit = rateLimit
+	}
+	key.DSN = sentry.ProjectKeyDSN{
+		Public:   fmt.Sprintf("http://%s@%s/%s", key.Public, host, p.ID),
+		Secret:   fmt.Sprintf("http://%s:%s@%s/%s", key.Public, key.Secret, host, p.ID),
+		CSP:      fmt.Sprintf("http://%s/api/%s/csp-report/?sentry_key=%s", host, p.ID, key.Public),
+		Security: fmt.Sprintf("http://%s/api/%s/security/?sentry_key=%s", host, p.ID, key.Public),
+		Minidump: fmt.Sprintf("http://%s/api/%s/minidump/?sentry_key=%s", host, p.ID, key.Public),
+		CDN:      fmt.Sprintf("http://%s/js-sdk-loader/%s.min.js", host, key.Public),
+	}
+	return &projectKey{ProjectKey: key, BrowserSdkVersion: "7.x"}
+}
+
+func (s *Server) listProjectKeys(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	keys := []*projectKey{}
+	keys = append(keys, p.keys...)
+	writeJSON(w, http.StatusOK, keys)
+}
+
+func (s *Server) createProjectKey(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var body sentry.CreateProjectKeyParams
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+
+	key := s.newProjectKey(p, body.Name, body.RateLimit)
+	p.keys = append(p.keys, key)
+	writeJSON(w, http.StatusCreated, key)
+}
+
+func (s *Server) getProjectKey(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	key, _ := p.lookupKey(params["key"])
+	if key == nil {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, key)
+}
+
+func (s *Server) updateProjectKey(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	key, _ := p.lookupKey(params["key"])
+	if key == nil {
+		writeNotFound(w)
+		return
+	}
+
+	var body struct {
+		sentry.UpdateProjectKeyParams
+		IsActive                *bool                `json:"isActive"`
+		BrowserSdkVersion       *string              `json:"browserSdkVersion"`
+		DynamicSdkLoaderOptions *loaderScriptOptions `json:"dynamicSdkLoaderOptions"`
+	}
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+	if body.BrowserSdkVersion != nil && !browserSdkVersions[*body.BrowserSdkVersion] {
+		writeValidationError(w, "browserSdkVersion", "\""+*body.BrowserSdkVersion+"\" is not a valid choice.")
+		return
+	}
+	if body.Name != "" {
+		key.Name = body.Name
+		key.Label = body.Name
+	}
+	if body.RateLimit != nil {
+		if body.RateLimit.Window == 0 && body.RateLimit.Count == 0 {
+			key.RateLimit = nil
+		} else {
+			key.RateLimit = body.RateLimit
+		}
+	}
+	if body.IsActive != nil {
+		key.IsActive = *body.IsActive
+	}
+	if body.BrowserSdkVersion != nil {
+		key.BrowserSdkVersion = *body.BrowserSdkVersion
+	}
+	if body.DynamicSdkLoaderOptions != nil {
+		key.DynamicSdkLoaderOptions = *body.DynamicSdkLoaderOptions
+	}
+	writeJSON(w, http.StatusOK, key)
+}
+
+func (s *Server) deleteProjectKey(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	_, i := p.lookupKey(params["key"])
+	if i < 0 {
+		writeNotFound(w)
+		return
+	}
+	p.keys = append(p.keys[:i], p.keys[i+1:]...)
+	writeJSON(w, http.StatusNoContent, nil)
+}
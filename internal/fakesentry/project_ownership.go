@@ -0,0 +1,91 @@
+package fakesentry
+
+import (
+	"net/http"
+	"time"
+)
+
+// ownershipAutoAssignments are the auto-assignment modes Sentry accepts.
+var ownershipAutoAssignments = map[string]bool{
+	"Auto Assign to Issue Owner":     true,
+	"Auto Assign to Suspect Commits": true,
+	"Turn off Auto-Assignment":       true,
+}
+
+type ownership struct {
+	Raw                string    `json:"raw"`
+	FallThrough        bool      `json:"fallthrough"`
+	AutoAssignment     string    `json:"autoAssignment"`
+	CodeownersAutoSync bool      `json:"codeownersAutoSync"`
+	IsActive           bool      `json:"isActive"`
+	DateCreated        time.Time `json:"dateCreated"`
+	LastUpdated        time.Time `json:"lastUpdated"`
+}
+
+// SetProjectOwnershipRules sets the ownership rules of a project, as if they
+// had been changed in the UI.
+func (s *Server) SetProjectOwnershipRules(org, slug, raw string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, ok := s.lookupProject(org, slug); ok {
+		p.ownership.Raw = raw
+	}
+}
+
+func (s *Server) registerProjectOwnershipRoutes() {
+	s.handle(http.MethodGet, "/api/0/projects/{org}/{project}/ownership/", s.getProjectOwnership)
+	s.handle(http.MethodPut, "/api/0/projects/{org}/{project}/ownership/", s.updateProjectOwnership)
+}
+
+func (s *Server) getProjectOwnership(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, p.ownership)
+}
+
+func (s *Server) updateProjectOwnership(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var body struct {
+		Raw                *string `json:"raw"`
+		FallThrough        *bool   `json:"fallthrough"`
+		AutoAssignment     *string `json:"autoAssignment"`
+		CodeownersAutoSync *bool   `json:"codeownersAutoSync"`
+	}
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+	if body.AutoAssignment != nil && !ownershipAutoAssignments[*body.AutoAssignment] {
+		writeValidationError(w, "autoAssignment", "Invalid auto-assignment mode.")
+		return
+	}
+
+	o := p.ownership
+	if body.Raw != nil {
+		o.Raw = *body.Raw
+	}
+	if body.FallThrough != nil {
+		o.FallThrough = *body.FallThrough
+	}
+	if body.AutoAssignment != nil {
+		o.AutoAssignment = *body.AutoAssignment
+	}
+	if body.CodeownersAutoSync != nil {
+		o.CodeownersAutoSync = *body.CodeownersAutoSync
+	}
+	now := time.Now().UTC()
+	if o.DateCreated.IsZero() {
+		o.DateCreated = now
+	}
+	o.LastUpdated = now
+	o.IsActive = true
+	writeJSON(w, http.StatusOK, o)
+}
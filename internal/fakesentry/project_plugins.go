@@ -0,0 +1,133 @@
+package fakesentry
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+func (s *Server) registerProjectPluginRoutes() {
+	s.handle(http.MethodGet, "/api/0/projects/{org}/{project}/plugins/", s.listProjectPlugins)
+	s.handle(http.MethodGet, "/api/0/projects/{org}/{project}/plugins/{plugin}/", s.getProjectPlugin)
+	s.handle(http.MethodPut, "/api/0/projects/{org}/{project}/plugins/{plugin}/", s.updateProjectPlugin)
+	s.handle(http.MethodPost, "/api/0/projects/{org}/{project}/plugins/{plugin}/", s.enableProjectPlugin)
+	s.handle(http.MethodDelete, "/api/0/projects/{org}/{project}/plugins/{plugin}/", s.disableProjectPlugin)
+}
+
+// projectPlugin returns the plugin with the given ID, registering it on first
+// use since every plugin is available to every project.
+func (p *project) projectPlugin(id string) *plugin {
+	pl, ok := p.plugins[id]
+	if !ok {
+		pl = &plugin{config: make(map[string]interface{})}
+		p.plugins[id] = pl
+	}
+	return pl
+}
+
+func (pl *plugin) serialize(id string) *sentry.ProjectPlugin {
+	status := "disabled"
+	if pl.enabled {
+		status = "enabled"
+	}
+
+	names := make([]string, 0, len(pl.config))
+	for name := range pl.config {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	config := make([]sentry.ProjectPluginConfig, 0, len(names))
+	for _, name := range names {
+		config = append(config, sentry.ProjectPluginConfig{
+			Name:  name,
+			Label: name,
+			Type:  "text",
+			Value: pl.config[name],
+		})
+	}
+
+	return &sentry.ProjectPlugin{
+		ID:         id,
+		Name:       id,
+		Type:       "notification",
+		CanDisable: true,
+		Status:     status,
+		Contexts:   []string{},
+		Assets:     []sentry.ProjectPluginAsset{},
+		Config:     config,
+	}
+}
+
+func (s *Server) listProjectPlugins(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	ids := make([]string, 0, len(p.plugins))
+	for id := range p.plugins {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	plugins := []*sentry.ProjectPlugin{}
+	for _, id := range ids {
+		plugins = append(plugins, p.plugins[id].serialize(id))
+	}
+	writeJSON(w, http.StatusOK, plugins)
+}
+
+func (s *Server) getProjectPlugin(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	id := params["plugin"]
+	writeJSON(w, http.StatusOK, p.projectPlugin(id).serialize(id))
+}
+
+func (s *Server) updateProjectPlugin(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var body map[string]interface{}
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+
+	id := params["plugin"]
+	pl := p.projectPlugin(id)
+	for k, v := range body {
+		pl.config[k] = v
+	}
+	writeJSON(w, http.StatusOK, pl.serialize(id))
+}
+
+func (s *Server) enableProjectPlugin(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	p.projectPlugin(params["plugin"]).enabled = true
+	writeJSON(w, http.StatusCreated, nil)
+}
+
+func (s *Server) disableProjectPlugin(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	pl := p.projectPlugin(params["plugin"])
+	pl.enabled = false
+	pl.config = make(map[string]interface{})
+	writeJSON(w, http.StatusNoContent, nil)
+}
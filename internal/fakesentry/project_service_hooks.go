@@ -0,0 +1,165 @@
+package fakesentry
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// serviceHookEvents are the events a service hook can subscribe to.
+var serviceHookEvents = map[string]bool{
+	"event.alert":   true,
+	"event.created": true,
+}
+
+type serviceHook struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url"`
+	Secret      string    `json:"secret"`
+	Status      string    `json:"status"`
+	Events      []string  `json:"events"`
+	DateCreated time.Time `json:"dateCreated"`
+}
+
+type serviceHookParams struct {
+	URL      *string  `json:"url"`
+	Events   []string `json:"events"`
+	IsActive *bool    `json:"isActive"`
+}
+
+func (s *Server) registerProjectServiceHookRoutes() {
+	s.handle(http.MethodGet, "/api/0/projects/{org}/{project}/hooks/", s.listProjectServiceHooks)
+	s.handle(http.MethodPost, "/api/0/projects/{org}/{project}/hooks/", s.createProjectServiceHook)
+	s.handle(http.MethodGet, "/api/0/projects/{org}/{project}/hooks/{hook}/", s.getProjectServiceHook)
+	s.handle(http.MethodPut, "/api/0/projects/{org}/{project}/hooks/{hook}/", s.updateProjectServiceHook)
+	s.handle(http.MethodDelete, "/api/0/projects/{org}/{project}/hooks/{hook}/", s.deleteProjectServiceHook)
+}
+
+// applyServiceHook validates the parameters and copies them onto h.
+func applyServiceHook(w http.ResponseWriter, params serviceHookParams, h *serviceHook) bool {
+	if params.URL == nil {
+		writeValidationError(w, "url", "This field is required.")
+		return false
+	}
+	if u, err := url.Parse(*params.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		writeValidationError(w, "url", "Enter a valid URL.")
+		return false
+	}
+	for _, event := range params.Events {
+		if !serviceHookEvents[event] {
+			writeValidationError(w, "events", "Invalid event name: "+event)
+			return false
+		}
+	}
+	h.URL = *params.URL
+	h.Events = params.Events
+	if params.IsActive != nil {
+		h.Status = "disabled"
+		if *params.IsActive {
+			h.Status = "active"
+		}
+	}
+	if h.Events == nil {
+		h.Events = []string{}
+	}
+	return true
+}
+
+func (s *Server) listProjectServiceHooks(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	list := p.serviceHooks
+	if list == nil {
+		list = []*serviceHook{}
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (s *Server) createProjectServiceHook(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var body serviceHookParams
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+	h := &serviceHook{
+		ID:          s.newID(),
+		Status:      "active",
+		DateCreated: time.Now().UTC(),
+	}
+	if !applyServiceHook(w, body, h) {
+		return
+	}
+	h.Secret = randomHex(32)
+	p.serviceHooks = append(p.serviceHooks, h)
+	writeJSON(w, http.StatusCreated, h)
+}
+
+func (s *Server) lookupServiceHook(p *project, id string) (int, bool) {
+	for i, h := range p.serviceHooks {
+		if h.ID == id {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func (s *Server) getProjectServiceHook(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	i, ok := s.lookupServiceHook(p, params["hook"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, p.serviceHooks[i])
+}
+
+func (s *Server) updateProjectServiceHook(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	i, ok := s.lookupServiceHook(p, params["hook"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	h := p.serviceHooks[i]
+	hookURL := h.URL
+	body := serviceHookParams{URL: &hookURL, Events: h.Events}
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+	if !applyServiceHook(w, body, h) {
+		return
+	}
+	writeJSON(w, http.StatusOK, h)
+}
+
+func (s *Server) deleteProjectServiceHook(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	i, ok := s.lookupServiceHook(p, params["hook"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	p.serviceHooks = append(p.serviceHooks[:i], p.serviceHooks[i+1:]...)
+	writeJSON(w, http.StatusNoContent, nil)
+}
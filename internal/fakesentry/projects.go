@@ -0,0 +1,434 @@
+package fakesentry
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+// AddProject seeds a project owned by the given team and returns its slug. The
+// organization and team must already exist.
+func (s *Server) AddProject(org, team, slug string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.newProject(s.organizations[org], s.teams[joinKey(org, team)], slug, slug, "")
+	s.projects[p.ID] = p
+	return p.Slug
+}
+
+// RenameProject changes the slug of a project, as if it had been changed in
+// the UI.
+func (s *Server) RenameProject(org, slug, newSlug string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, ok := s.lookupProject(org, slug); ok {
+		p.Slug = newSlug
+	}
+}
+
+// SetProjectOption sets an option of a project, as if it had been changed
+// in the UI.
+func (s *Server) SetProjectOption(org, slug, key string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, ok := s.lookupProject(org, slug); ok {
+		p.Options[key] = value
+	}
+}
+
+// ProjectOption returns an option of a project, decoded from JSON.
+func (s *Server) ProjectOption(org, slug, key string) (interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.lookupProject(org, slug)
+	if !ok {
+		return nil, false
+	}
+	v, ok := p.Options[key]
+	return v, ok
+}
+
+// SetProjectFingerprintingRules sets the fingerprint rules of a project, as
+// if they had been changed in the UI.
+func (s *Server) SetProjectFingerprintingRules(org, slug, rules string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, ok := s.lookupProject(org, slug); ok {
+		p.fingerprintingRules = rules
+	}
+}
+
+func (s *Server) newProject(org *sentry.Organization, team *sentry.Team, name, slug, platform string) *project {
+	return &project{
+		Project: &sentry.Project{
+			ID:                   s.newID(),
+			Slug:                 slug,
+			Name:                 name,
+			Platform:             platform,
+			Color:                "#3f70bf",
+			DateCreated:          time.Now().UTC(),
+			Features:             []string{},
+			Status:               "active",
+			IsMember:             true,
+			HasAccess:            true,
+			Options:              map[string]interface{}{},
+			DataScrubber:         true,
+			DataScrubberDefaults: true,
+			SafeFields:           []string{},
+			SensitiveFields:      []string{},
+			DigestsMinDelay:      300,
+			DigestsMaxDelay:      1800,
+			AllowedDomains:       []string{"*"},
+			ScrapeJavaScript:     true,
+			SecurityToken:        randomHex(16),
+			Organization:         *org,
+			Team:                 *team,
+			Teams:                []sentry.Team{*team},
+		},
+		plugins:        make(map[string]*plugin),
+		filters:        newProjectFilters(),
+		groupingConfig: "newstyle:2023-01-11",
+		ownership: &ownership{
+			FallThrough:        true,
+			AutoAssignment:     "Auto Assign to Issue Owner",
+			CodeownersAutoSync: true,
+		},
+	}
+}
+
+// securityTokenHeaderPattern matches the security token headers Sentry
+// accepts.
+var securityTokenHeaderPattern = regexp.MustCompile(`^[\w-]{0,20}$`)
+
+// groupingConfigs are the grouping configs Sentry accepts.
+var groupingConfigs = map[string]bool{
+	"newstyle:2023-01-11": true,
+	"newstyle:2019-10-29": true,
+	"mobile:2021-02-12":   true,
+	"legacy:2019-03-12":   true,
+}
+
+// projectDetails is a project as returned by the project details endpoint,
+// which go-sentry does not fully support.
+type projectDetails struct {
+	*sentry.Project
+	RelayPiiConfig      *string `json:"relayPiiConfig"`
+	FingerprintingRules string  `json:"fingerprintingRules"`
+	GroupingConfig      string  `json:"groupingConfig"`
+}
+
+func (p *project) details() *projectDetails {
+	details := &projectDetails{
+		Project:             p.Project,
+		FingerprintingRules: p.fingerprintingRules,
+		GroupingConfig:      p.groupingConfig,
+	}
+	if p.relayPiiConfig != "" {
+		details.RelayPiiConfig = sentry.String(p.relayPiiConfig)
+	}
+	return details
+}
+
+func (s *Server) registerProjectRoutes() {
+	s.handle(http.MethodGet, "/api/0/projects/", s.listProjects)
+	s.handle(http.MethodGet, "/api/0/organizations/{org}/projects/", s.listOrganizationProjects)
+	s.handle(http.MethodPost, "/api/0/teams/{org}/{team}/projects/", s.createProject)
+	s.handle(http.MethodGet, "/api/0/projects/{org}/{project}/", s.getProject)
+	s.handle(http.MethodPut, "/api/0/projects/{org}/{project}/", s.updateProject)
+	s.handle(http.MethodDelete, "/api/0/projects/{org}/{project}/", s.deleteProject)
+	s.handle(http.MethodPost, "/api/0/projects/{org}/{project}/teams/{team}/", s.addProjectTeam)
+	s.handle(http.MethodDelete, "/api/0/projects/{org}/{project}/teams/{team}/", s.removeProjectTeam)
+}
+
+// lookupProject returns the project with the given organization and project slug.
+func (s *Server) lookupProject(org, slug string) (*project, bool) {
+	for _, p := range s.projects {
+		if sentry.StringValue(p.Organization.Slug) == org && p.Slug == slug {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+func (s *Server) sortedProjects() []*project {
+	projects := make([]*project, 0, len(s.projects))
+	for _, p := range s.projects {
+		projects = append(projects, p)
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		a, _ := strconv.Atoi(projects[i].ID)
+		b, _ := strconv.Atoi(projects[j].ID)
+		return a < b
+	})
+	return projects
+}
+
+func (p *project) removeTeam(teamID string) {
+	teams := p.Teams[:0]
+	for _, team := range p.Teams {
+		if sentry.StringValue(team.ID) != teamID {
+			teams = append(teams, team)
+		}
+	}
+	p.Teams = teams
+	if sentry.StringValue(p.Team.ID) == teamID {
+		p.Team = sentry.Team{}
+		if len(p.Teams) > 0 {
+			p.Team = p.Teams[0]
+		}
+	}
+}
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	projects := []*sentry.Project{}
+	for _, p := range s.sortedProjects() {
+		projects = append(projects, p.Project)
+	}
+	writeJSON(w, http.StatusOK, projects)
+}
+
+// listOrganizationProjects supports the id:<id> and slug:<slug> queries.
+func (s *Server) listOrganizationProjects(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if _, ok := s.organizations[params["org"]]; !ok {
+		writeNotFound(w)
+		return
+	}
+
+	query := r.URL.Query().Get("query")
+	projects := []*sentry.Project{}
+	for _, p := range s.sortedProjects() {
+		if sentry.StringValue(p.Organization.Slug) != params["org"] {
+			continue
+		}
+		if query != "" && query != "id:"+p.ID && query != "slug:"+p.Slug {
+			continue
+		}
+		projects = append(projects, p.Project)
+	}
+	writeJSON(w, http.StatusOK, projects)
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	org, ok := s.organizations[params["org"]]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	team, ok := s.teams[joinKey(params["org"], params["team"])]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var body struct {
+		sentry.CreateProjectParams
+		DefaultRules *bool `json:"default_rules"`
+	}
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+	if body.Name == "" {
+		writeValidationError(w, "name", "This field is required.")
+		return
+	}
+
+	slug := body.Slug
+	if slug == "" {
+		slug = slugify(body.Name)
+	}
+	if _, ok := s.lookupProject(*org.Slug, slug); ok {
+		writeDetail(w, http.StatusConflict, "A project with this slug already exists.")
+		return
+	}
+
+	p := s.newProject(org, team, body.Name, slug, body.Platform)
+	if body.DefaultRules == nil || *body.DefaultRules {
+		p.issueAlerts = append(p.issueAlerts, s.newDefaultIssueAlert(p))
+	}
+	s.projects[p.ID] = p
+	writeJSON(w, http.StatusCreated, p.Project)
+}
+
+func (s *Server) getProject(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, p.details())
+}
+
+func (s *Server) updateProject(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var body map[string]json.RawMessage
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+
+	var update sentry.UpdateProjectParams
+	raw, _ := json.Marshal(body)
+	if err := json.Unmarshal(raw, &update); err != nil {
+		writeDetail(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var grouping struct {
+		FingerprintingRules *string `json:"fingerprintingRules"`
+		GroupingConfig      *string `json:"groupingConfig"`
+	}
+	if err := json.Unmarshal(raw, &grouping); err != nil {
+		writeDetail(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if grouping.GroupingConfig != nil && !groupingConfigs[*grouping.GroupingConfig] {
+		writeValidationError(w, "groupingConfig", "Unknown grouping config.")
+		return
+	}
+	var security struct {
+		ScrapeJavaScript    *bool   `json:"scrapeJavaScript"`
+		SecurityToken       *string `json:"securityToken"`
+		SecurityTokenHeader *string `json:"securityTokenHeader"`
+		VerifySSL           *bool   `json:"verifySSL"`
+	}
+	if err := json.Unmarshal(raw, &security); err != nil {
+		writeDetail(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if security.SecurityTokenHeader != nil && !securityTokenHeaderPattern.MatchString(*security.SecurityTokenHeader) {
+		writeValidationError(w, "securityTokenHeader", "Enter a valid value.")
+		return
+	}
+	var scrubbing dataScrubbingParams
+	if err := json.Unmarshal(raw, &scrubbing); err != nil {
+		writeDetail(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !scrubbing.validate(w) {
+		return
+	}
+
+	if _, ok := body["slug"]; ok && update.Slug != p.Slug {
+		if _, ok := s.lookupProject(params["org"], update.Slug); ok {
+			writeValidationError(w, "slug", "Another project ("+update.Slug+") is already using that slug")
+			return
+		}
+		p.Slug = update.Slug
+	}
+	if _, ok := body["name"]; ok {
+		p.Name = update.Name
+	}
+	if _, ok := body["platform"]; ok {
+		p.Platform = update.Platform
+	}
+	if update.DigestsMinDelay != nil {
+		p.DigestsMinDelay = *update.DigestsMinDelay
+	}
+	if update.DigestsMaxDelay != nil {
+		p.DigestsMaxDelay = *update.DigestsMaxDelay
+	}
+	if update.ResolveAge != nil {
+		p.ResolveAge = *update.ResolveAge
+	}
+	if update.IsBookmarked != nil {
+		p.IsBookmarked = *update.IsBookmarked
+	}
+	if _, ok := body["allowedDomains"]; ok {
+		p.AllowedDomains = update.AllowedDomains
+	}
+	if _, ok := body["groupingEnhancements"]; ok {
+		p.GroupingEnhancements = update.GroupingEnhancements
+	}
+	for k, v := range update.Options {
+		if v == nil {
+			delete(p.Options, k)
+			continue
+		}
+		p.Options[k] = v
+	}
+	if security.ScrapeJavaScript != nil {
+		p.ScrapeJavaScript = *security.ScrapeJavaScript
+	}
+	if security.SecurityToken != nil {
+		p.SecurityToken = *security.SecurityToken
+	}
+	if security.SecurityTokenHeader != nil {
+		p.SecurityTokenHeader = security.SecurityTokenHeader
+	}
+	if security.VerifySSL != nil {
+		p.VerifySSL = *security.VerifySSL
+	}
+	if grouping.FingerprintingRules != nil {
+		p.fingerprintingRules = *grouping.FingerprintingRules
+	}
+	if grouping.GroupingConfig != nil {
+		p.groupingConfig = *grouping.GroupingConfig
+	}
+	p.updateDataScrubbing(&scrubbing)
+
+	writeJSON(w, http.StatusOK, p.details())
+}
+
+func (s *Server) deleteProject(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	delete(s.projects, p.ID)
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+func (s *Server) addProjectTeam(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	team, ok := s.teams[joinKey(params["org"], params["team"])]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	for _, t := range p.Teams {
+		if sentry.StringValue(t.ID) == *team.ID {
+			writeJSON(w, http.StatusCreated, p.Project)
+			return
+		}
+	}
+	p.Teams = append(p.Teams, *team)
+	if p.Team.ID == nil {
+		p.Team = *team
+	}
+	writeJSON(w, http.StatusCreated, p.Project)
+}
+
+func (s *Server) removeProjectTeam(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(params["org"], params["project"])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	team, ok := s.teams[joinKey(params["org"], params["team"])]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	p.removeTeam(*team.ID)
+	writeJSON(w, http.StatusOK, p.Project)
+}
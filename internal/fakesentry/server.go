@@ -0,0 +1,336 @@
+// Package fakesentry provides an in-memory stand-in for the Sentry API.
+//
+// The server implements the subset of endpoints used by the provider with
+// just enough fidelity to drive full create, read, update, import and delete
+// cycles without a live Sentry organization. All state lives in memory and is
+// discarded when the server is closed.
+package fakesentry
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+// Server is an in-memory Sentry API server.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	nextID   int
+	routes   []route
+	requests int
+	failures []int // status codes of the next responses to fail
+
+	// Guarded by limitMu, which is held only briefly so that it can be
+	// taken outside of handlers.
+	limitMu         sync.Mutex
+	latency         time.Duration
+	concurrentLimit int
+	inFlight        int
+	peakInFlight    int
+
+	organizations map[string]*sentry.Organization // keyed by organization slug
+	teams         map[string]*sentry.Team         // keyed by organization/team slug
+	projects      map[string]*project             // keyed by project ID
+	dashboards    map[string]*sentry.Dashboard    // keyed by organization/dashboard ID
+	repositories  map[string]*sentry.OrganizationRepository
+	codeMappings  map[string]*sentry.OrganizationCodeMapping
+	integrations  map[string]*sentry.OrganizationIntegration
+}
+
+// project holds a project along with the objects scoped to it, so that
+// renaming the project slug keeps its children attached.
+type project struct {
+	*sentry.Project
+
+	keys         []*projectKey
+	issueAlerts  []*sentry.IssueAlert
+	metricAlerts []*sentry.MetricAlert
+	plugins      map[string]*plugin
+	filters      map[string]interface{}
+	ownership    *ownership
+	codeowners   []*codeowners
+	serviceHooks []*serviceHook
+
+	relayPiiConfig      string
+	fingerprintingRules string
+	groupingConfig      string
+}
+
+type plugin struct {
+	enabled bool
+	config  map[string]interface{}
+}
+
+// NewServer starts and returns a new Server. The caller should call Close when
+// finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		organizations: make(map[string]*sentry.Organization),
+		teams:         make(map[string]*sentry.Team),
+		projects:      make(map[string]*project),
+		dashboards:    make(map[string]*sentry.Dashboard),
+		repositories:  make(map[string]*sentry.OrganizationRepository),
+		codeMappings:  make(map[string]*sentry.OrganizationCodeMapping),
+		integrations:  make(map[string]*sentry.OrganizationIntegration),
+	}
+	s.registerRoutes()
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// BaseURL returns the API base URL to configure the provider with.
+func (s *Server) BaseURL() string {
+	return s.URL + "/api/"
+}
+
+type handlerFunc func(w http.ResponseWriter, r *http.Request, params map[string]string)
+
+type route struct {
+	method   string
+	segments []string
+	handler  handlerFunc
+}
+
+func (s *Server) handle(method, pattern string, handler handlerFunc) {
+	s.routes = append(s.routes, route{
+		method:   method,
+		segments: strings.Split(strings.Trim(pattern, "/"), "/"),
+		handler:  handler,
+	})
+}
+
+func (s *Server) registerRoutes() {
+	s.registerOrganizationRoutes()
+	s.registerTeamRoutes()
+	s.registerProjectRoutes()
+	s.registerProjectKeyRoutes()
+	s.registerIssueAlertRoutes()
+	s.registerMetricAlertRoutes()
+	s.registerDashboardRoutes()
+	s.registerProjectPluginRoutes()
+	s.registerProjectFilterRoutes()
+	s.registerProjectOwnershipRoutes()
+	s.registerProjectCodeownersRoutes()
+	s.registerProjectServiceHookRoutes()
+	s.registerOrganizationRepositoryRoutes()
+	s.registerOrganizationCodeMappingRoutes()
+	s.registerOrganizationIntegrationRoutes()
+}
+
+// FailNext makes the next n requests fail with the given status code before
+// reaching their handler.
+func (s *Server) FailNext(n, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := 0; i < n; i++ {
+		s.failures = append(s.failures, status)
+	}
+}
+
+// Requests returns the number of requests received so far.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests
+}
+
+// SetLatency delays every response by d. Delayed requests are processed
+// concurrently.
+func (s *Server) SetLatency(d time.Duration) {
+	s.limitMu.Lock()
+	defer s.limitMu.Unlock()
+
+	s.latency = d
+}
+
+// SetConcurrentLimit enforces a limit on concurrent requests the way Sentry
+// does, reporting it in the X-Sentry-Rate-Limit-ConcurrentLimit and
+// -ConcurrentRemaining response headers and rejecting requests over the limit
+// with 429 Too Many Requests. Zero disables the limit.
+func (s *Server) SetConcurrentLimit(n int) {
+	s.limitMu.Lock()
+	defer s.limitMu.Unlock()
+
+	s.concurrentLimit = n
+}
+
+// PeakConcurrentRequests returns the highest number of requests that were
+// processed at the same time.
+func (s *Server) PeakConcurrentRequests() int {
+	s.limitMu.Lock()
+	defer s.limitMu.Unlock()
+
+	return s.peakInFlight
+}
+
+// enter registers a request in flight and applies the concurrent limit. It
+// returns false if the request has been rejected.
+func (s *Server) enter(w http.ResponseWriter) (time.Duration, bool) {
+	s.limitMu.Lock()
+	defer s.limitMu.Unlock()
+
+	s.inFlight++
+	if s.inFlight > s.peakInFlight {
+		s.peakInFlight = s.inFlight
+	}
+	if s.concurrentLimit == 0 {
+		return s.latency, true
+	}
+
+	remaining := s.concurrentLimit - s.inFlight
+	if remaining < 0 {
+		remaining = 0
+	}
+	w.Header().Set("X-Sentry-Rate-Limit-ConcurrentLimit", strconv.Itoa(s.concurrentLimit))
+	w.Header().Set("X-Sentry-Rate-Limit-ConcurrentRemaining", strconv.Itoa(remaining))
+	if s.inFlight > s.concurrentLimit {
+		writeDetail(w, http.StatusTooManyRequests, "You are attempting to go above the allowed concurrency for this endpoint.")
+		return 0, false
+	}
+	return s.latency, true
+}
+
+func (s *Server) leave() {
+	s.limitMu.Lock()
+	defer s.limitMu.Unlock()
+
+	s.inFlight--
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	latency, ok := s.enter(w)
+	defer s.leave()
+	if !ok {
+		return
+	}
+	time.Sleep(latency)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests++
+	if len(s.failures) > 0 {
+		status := s.failures[0]
+		s.failures = s.failures[1:]
+		writeDetail(w, status, http.StatusText(status))
+		return
+	}
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	pathMatched := false
+	for _, rt := range s.routes {
+		params, ok := matchSegments(rt.segments, segments)
+		if !ok {
+			continue
+		}
+		pathMatched = true
+		if rt.method != r.Method {
+			continue
+		}
+
+		rt.handler(w, r, params)
+		return
+	}
+
+	if pathMatched {
+		writeDetail(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method \"%s\" not allowed.", r.Method))
+		return
+	}
+	writeNotFound(w)
+}
+
+func matchSegments(pattern, segments []string) (map[string]string, bool) {
+	if len(pattern) != len(segments) {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+	for i, p := range pattern {
+		if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
+			params[p[1:len(p)-1]] = segments[i]
+			continue
+		}
+		if p != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func (s *Server) newID() string {
+	s.nextID++
+	return strconv.Itoa(s.nextID)
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+var slugifyRegexp = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+func slugify(name string) string {
+	return strings.Trim(slugifyRegexp.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+func decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if r.Body == nil {
+		return true
+	}
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		writeDetail(w, http.StatusBadRequest, fmt.Sprintf("JSON parse error - %s", err))
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if v != nil {
+		json.NewEncoder(w).Encode(v)
+	}
+}
+
+func writeDetail(w http.ResponseWriter, status int, detail string) {
+	writeJSON(w, status, map[string]string{"detail": detail})
+}
+
+func writeNotFound(w http.ResponseWriter) {
+	writeDetail(w, http.StatusNotFound, "The requested resource does not exist")
+}
+
+// writeValidationError responds with a field-keyed error body, the format
+// Sentry's serializers use for invalid input.
+func writeValidationError(w http.ResponseWriter, field string, messages ...string) {
+	writeJSON(w, http.StatusBadRequest, map[string][]string{field: messages})
+}
+
+func joinKey(parts ...string) string {
+	return strings.Join(parts, "/")
+}
+
+func splitKey(key string) (string, string) {
+	parts := strings.SplitN(key, "/", 2)
+	return parts[0], parts[1]
+}
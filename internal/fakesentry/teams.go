@@ -0,0 +1,156 @@
+package fakesentry
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+// AddTeam seeds a team in the given organization and returns its slug.
+func (s *Server) AddTeam(org, slug string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.teams[joinKey(org, slug)] = newTeam(s.newID(), slug, slug)
+	return slug
+}
+
+func newTeam(id, slug, name string) *sentry.Team {
+	return &sentry.Team{
+		ID:          sentry.String(id),
+		Slug:        sentry.String(slug),
+		Name:        sentry.String(name),
+		DateCreated: sentry.Time(time.Now().UTC()),
+		IsMember:    sentry.Bool(true),
+		HasAccess:   sentry.Bool(true),
+		IsPending:   sentry.Bool(false),
+		MemberCount: sentry.Int(1),
+	}
+}
+
+func (s *Server) registerTeamRoutes() {
+	s.handle(http.MethodGet, "/api/0/organizations/{org}/teams/", s.listTeams)
+	s.handle(http.MethodPost, "/api/0/organizations/{org}/teams/", s.createTeam)
+	s.handle(http.MethodGet, "/api/0/teams/{org}/{team}/", s.getTeam)
+	s.handle(http.MethodPut, "/api/0/teams/{org}/{team}/", s.updateTeam)
+	s.handle(http.MethodDelete, "/api/0/teams/{org}/{team}/", s.deleteTeam)
+}
+
+func (s *Server) listTeams(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	org := params["org"]
+	if _, ok := s.organizations[org]; !ok {
+		writeNotFound(w)
+		return
+	}
+
+	teams := []*sentry.Team{}
+	for key, team := range s.teams {
+		if orgSlug, _ := splitKey(key); orgSlug == org {
+			teams = append(teams, team)
+		}
+	}
+	sort.Slice(teams, func(i, j int) bool {
+		return *teams[i].Slug < *teams[j].Slug
+	})
+	writeJSON(w, http.StatusOK, teams)
+}
+
+func (s *Server) createTeam(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	org := params["org"]
+	if _, ok := s.organizations[org]; !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var body sentry.CreateTeamParams
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+	if sentry.StringValue(body.Name) == "" && sentry.StringValue(body.Slug) == "" {
+		writeValidationError(w, "slug", "This field is required.")
+		return
+	}
+
+	slug := sentry.StringValue(body.Slug)
+	if slug == "" {
+		slug = slugify(*body.Name)
+	}
+	name := sentry.StringValue(body.Name)
+	if name == "" {
+		name = slug
+	}
+	if _, ok := s.teams[joinKey(org, slug)]; ok {
+		writeDetail(w, http.StatusConflict, "A team with this slug already exists.")
+		return
+	}
+
+	team := newTeam(s.newID(), slug, name)
+	s.teams[joinKey(org, slug)] = team
+	writeJSON(w, http.StatusCreated, team)
+}
+
+func (s *Server) getTeam(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	team, ok := s.teams[joinKey(params["org"], params["team"])]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, team)
+}
+
+func (s *Server) updateTeam(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	org := params["org"]
+	team, ok := s.teams[joinKey(org, params["team"])]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var body sentry.UpdateTeamParams
+	if !decodeJSON(w, r, &body) {
+		return
+	}
+	if body.Slug != nil && *body.Slug != "" && *body.Slug != *team.Slug {
+		if _, ok := s.teams[joinKey(org, *body.Slug)]; ok {
+			writeValidationError(w, "slug", "Another team is already using that slug.")
+			return
+		}
+		delete(s.teams, joinKey(org, *team.Slug))
+		team.Slug = body.Slug
+		s.teams[joinKey(org, *team.Slug)] = team
+	}
+	if body.Name != nil && *body.Name != "" {
+		team.Name = body.Name
+	}
+
+	// Keep the copies embedded in projects in sync.
+	for _, p := range s.projects {
+		for i := range p.Teams {
+			if sentry.StringValue(p.Teams[i].ID) == *team.ID {
+				p.Teams[i] = *team
+			}
+		}
+		if sentry.StringValue(p.Team.ID) == *team.ID {
+			p.Team = *team
+		}
+	}
+
+	writeJSON(w, http.StatusOK, team)
+}
+
+func (s *Server) deleteTeam(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	key := joinKey(params["org"], params["team"])
+	team, ok := s.teams[key]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	delete(s.teams, key)
+	for _, p := range s.projects {
+		p.removeTeam(*team.ID)
+	}
+	writeJSON(w, http.StatusNoContent, nil)
+}
@@ -6,57 +6,211 @@ import (
 	"sync"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/jianyuan/go-sentry/v2/sentry"
 	"golang.org/x/oauth2"
-	"golang.org/x/sync/semaphore"
 )
 
 // Config is the configuration structure used to instantiate the Sentry
 // provider.
 type Config struct {
-	UserAgent string
-	Token     string
-	BaseURL   string
+	UserAgent    string
+	Token        string
+	BaseURL      string
+	Organization string
+	PlatformsURL string
+
+	// MaxRetries is the maximum number of retries for a request that failed
+	// with a rate limit, a server error or a connection error.
+	MaxRetries int
+	// MinRetryWait and MaxRetryWait bound the exponential backoff between
+	// retries. Rate limited requests wait until the limit resets instead.
+	MinRetryWait time.Duration
+	MaxRetryWait time.Duration
+	// MaxConcurrentRequests caps the number of requests in flight. When zero,
+	// the limit reported by Sentry is used, or defaultMaxConcurrentRequests
+	// until Sentry reports one.
+	MaxConcurrentRequests int
+	// RequestTimeout bounds the time Sentry takes to respond to each attempt
+	// of a request. Zero means no timeout.
+	RequestTimeout time.Duration
+
+	// ReadOnly rejects every request that would modify Sentry.
+	ReadOnly bool
+	// WritableResources, if not empty, holds the only resource types allowed
+	// to modify Sentry.
+	WritableResources []string
+
+	// LogHTTPBodies includes request and response bodies in the HTTP logs.
+	LogHTTPBodies bool
+	// LogHTTPFile, if set, is the path of a file the HTTP logs are appended
+	// to, in addition to the TRACE logs.
+	LogHTTPFile string
+}
+
+// ProviderData is passed as meta to every resource and data source.
+type ProviderData struct {
+	// Client is not bound to any context. Resources and data sources use
+	// ClientWithContext instead, so that their requests honour timeouts.
+	Client *sentry.Client
+
+	httpClient *http.Client
+
+	// Organization is the default organization slug, used when a resource or
+	// data source does not set its own.
+	Organization string
+
+	// PlatformsURL, if set, serves platforms accepted by the server in
+	// addition to the embedded catalogue.
+	PlatformsURL string
+
+	platformsOnce   sync.Once
+	serverPlatforms platformSet
+}
+
+// ProviderData returns the data shared with resources and data sources.
+func (c *Config) ProviderData(ctx context.Context) (interface{}, diag.Diagnostics) {
+	httpClient, diags := c.HTTPClient(ctx)
+	if diags.HasError() {
+		return nil, diags
+	}
+	client, diags := c.newClient(httpClient)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return &ProviderData{
+		Client:       client,
+		httpClient:   httpClient,
+		Organization: c.Organization,
+		PlatformsURL: c.PlatformsURL,
+	}, diags
+}
+
+// retryBudget returns the longest time a request may take with its retries,
+// not counting the attempts without a RequestTimeout nor the waits for rate
+// limits to reset.
+func (c *Config) retryBudget() time.Duration {
+	return time.Duration(c.MaxRetries)*c.MaxRetryWait + time.Duration(c.MaxRetries+1)*c.RequestTimeout
+}
+
+// ClientWithContext returns a client whose requests are bound to ctx, so
+// that they are abandoned once ctx is done, for instance when the operation
+// of a resource times out. The go-sentry client does not pass the context of
+// its methods on to the requests it makes.
+func (p *ProviderData) ClientWithContext(ctx context.Context) *sentry.Client {
+	if p.httpClient == nil {
+		return p.Client
+	}
+
+	cl := sentry.NewClient(&http.Client{
+		Transport: &contextTransport{ctx: ctx, Delegate: p.httpClient.Transport},
+	})
+	cl.BaseURL = p.Client.BaseURL
+	cl.UserAgent = p.Client.UserAgent
+	return cl
+}
+
+// contextTransport binds requests to a context.
+type contextTransport struct {
+	ctx      context.Context
+	Delegate http.RoundTripper
+}
+
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.Delegate.RoundTrip(req.WithContext(t.ctx))
 }
 
 // Client to connect to Sentry.
-func (c *Config) Client(ctx context.Context) (interface{}, diag.Diagnostics) {
+func (c *Config) Client(ctx context.Context) (*sentry.Client, diag.Diagnostics) {
+	httpClient, diags := c.HTTPClient(ctx)
+	if diags.HasError() {
+		return nil, diags
+	}
+	return c.newClient(httpClient)
+}
+
+// HTTPClient returns the HTTP client used to talk to Sentry, which handles
+// authentication, retries, the concurrency limit and logging.
+func (c *Config) HTTPClient(ctx context.Context) (*http.Client, diag.Diagnostics) {
 	tflog.Info(ctx, "Instantiating Sentry client...")
 
+	// Bound the time Sentry takes to respond to each attempt, excluding the
+	// time spent waiting for a concurrency slot or between retries.
+	baseTransport := http.DefaultTransport.(*http.Transport).Clone()
+	baseTransport.ResponseHeaderTimeout = c.RequestTimeout
+
+	// Log each attempt as sent, with the token redacted.
+	loggingTransport, err := newLoggingTransport(baseTransport, c.LogHTTPBodies, c.LogHTTPFile)
+	if err != nil {
+		return nil, diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       "Could not open the HTTP log file",
+				Detail:        err.Error(),
+				AttributePath: cty.GetAttrPath("log_http_file"),
+			},
+		}
+	}
+
 	// Authentication
 	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: c.Token})
-	oauth2HTTPClient := oauth2.NewClient(ctx, ts)
+	oauth2HTTPClient := oauth2.NewClient(context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: loggingTransport}), ts)
+
+	// Handle concurrency limit. Each attempt takes a slot, so that requests
+	// waiting to be retried do not hold on to one.
+	oauth2HTTPClient.Transport = &semaphoreTransport{
+		Delegate:     oauth2HTTPClient.Transport,
+		DefaultLimit: defaultMaxConcurrentRequests,
+		MaxLimit:     c.MaxConcurrentRequests,
+	}
 
 	// Handle rate limit
 	retryClient := retryablehttp.NewClient()
 	retryClient.HTTPClient = oauth2HTTPClient
 	retryClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
-	retryClient.Logger = nil // Disable DEBUG logs
+	retryClient.Logger = nil // Disable DEBUG logs, attempts are logged by loggingTransport
+	retryClient.RetryMax = c.MaxRetries
+	retryClient.RetryWaitMin = c.MinRetryWait
+	retryClient.RetryWaitMax = c.MaxRetryWait
 	retryClient.Backoff = func(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
-		if rateLimitErr, ok := sentry.CheckResponse(resp).(*sentry.RateLimitError); ok {
-			return time.Until(rateLimitErr.Rate.Reset)
+		// There is no response after a connection error or a request timeout.
+		if resp != nil {
+			if rateLimitErr, ok := sentry.CheckResponse(resp).(*sentry.RateLimitError); ok && !rateLimitErr.Rate.Reset.IsZero() {
+				return time.Until(rateLimitErr.Rate.Reset)
+			}
 		}
 		return retryablehttp.DefaultBackoff(min, max, attemptNum, resp)
 	}
-	retryHTTPClient := retryClient.StandardClient()
+	httpClient := retryClient.StandardClient()
+	httpClient.Transport = &attemptCountingTransport{Delegate: httpClient.Transport}
 
-	// Handle concurrency limit
-	semaphoreHTTPClient := &http.Client{
-		Transport: &semaphoreTransport{
-			Delegate: retryHTTPClient.Transport,
-		},
+	// Reject changes before they are attempted.
+	if c.ReadOnly || len(c.WritableResources) > 0 {
+		writable := make(map[string]bool, len(c.WritableResources))
+		for _, name := range c.WritableResources {
+			writable[name] = true
+		}
+		httpClient.Transport = &writeGuardTransport{
+			Delegate:          httpClient.Transport,
+			ReadOnly:          c.ReadOnly,
+			WritableResources: writable,
+		}
 	}
 
-	// Initialize client
+	return httpClient, nil
+}
+
+func (c *Config) newClient(httpClient *http.Client) (*sentry.Client, diag.Diagnostics) {
 	var cl *sentry.Client
 	var err error
 	if c.BaseURL == "" {
-		cl = sentry.NewClient(semaphoreHTTPClient)
+		cl = sentry.NewClient(httpClient)
 	} else {
-		cl, err = sentry.NewOnPremiseClient(c.BaseURL, semaphoreHTTPClient)
+		cl, err = sentry.NewOnPremiseClient(c.BaseURL, httpClient)
 		if err != nil {
 			return nil, diag.FromErr(err)
 		}
@@ -67,36 +221,3 @@ func (c *Config) Client(ctx context.Context) (interface{}, diag.Diagnostics) {
 
 	return cl, nil
 }
-
-type semaphoreTransport struct {
-	Delegate http.RoundTripper
-
-	mu sync.RWMutex
-	w  *semaphore.Weighted
-}
-
-func (t *semaphoreTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	t.mu.RLock()
-	if t.w == nil {
-		t.mu.RUnlock()
-		t.mu.Lock()
-		resp, err := t.Delegate.RoundTrip(req)
-		if resp != nil {
-			rate := sentry.ParseRate(resp)
-			if rate.ConcurrentLimit > 0 {
-				t.w = semaphore.NewWeighted(int64(rate.ConcurrentLimit))
-			}
-		}
-		t.mu.Unlock()
-		return resp, err
-	}
-	t.mu.RUnlock()
-
-	ctx := req.Context()
-	if err := t.w.Acquire(ctx, 1); err != nil {
-		return nil, err
-	}
-	defer t.w.Release(1)
-
-	return t.Delegate.RoundTrip(req)
-}
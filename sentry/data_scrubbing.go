@@ -0,0 +1,410 @@
+package sentry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+// dataScrubbingKeys are the attributes of dataScrubbingSchema.
+var dataScrubbingKeys = []string{
+	"data_scrubber",
+	"data_scrubber_defaults",
+	"scrub_ip_addresses",
+	"sensitive_fields",
+	"safe_fields",
+	"rule",
+}
+
+// dataScrubbingSchema returns the data scrubbing attributes shared by
+// projects and organizations. Unless authoritative, attributes left unset
+// keep their value in Sentry.
+func dataScrubbingSchema(authoritative bool) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"data_scrubber": {
+			Description: "Whether to remove data that looks like passwords, credit card numbers and other " +
+				"sensitive data from events.",
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		"data_scrubber_defaults": {
+			Description: "Whether to apply the default scrubbers of Sentry, which remove fields such as " +
+				"`password` and `secret`.",
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		"scrub_ip_addresses": {
+			Description: "Whether to prevent IP addresses from being stored with events.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+		},
+		"sensitive_fields": {
+			Description: "Additional field names to remove the values of.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"safe_fields": {
+			Description: "Field names the data scrubbers must leave alone.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"rule": {
+			Description: "Advanced data scrubbing rules, applied in order.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"type": {
+						Description: "The kind of data to scrub: `pattern` for a regular expression, or one of " +
+							"`creditcard`, `password`, `ip`, `imei`, `email`, `uuid`, `pemkey`, `url_auth`, " +
+							"`usssn`, `userpath`, `mac` and `anything`. Other types of Sentry, such as `redactPair`, " +
+							"are passed through with the fields in `extra`.",
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+					"method": {
+						Description:  "How to scrub the data: `mask`, `remove`, `hash` or `replace`.",
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice([]string{"mask", "remove", "hash", "replace"}, false),
+					},
+					"source": {
+						Description: "The event fields the rule applies to, such as `$string`, `$http.headers.x-auth` " +
+							"or `$frame.vars`.",
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"pattern": {
+						Description: "The regular expression matching the data to scrub. Required by `pattern` rules.",
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+					"replacement": {
+						Description: "The text to replace the data with. Only used by the `replace` method.",
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+					"extra": {
+						Description: "The other fields of the rule as a JSON object, such as `keyPattern` for " +
+							"`redactPair` rules.",
+						Type:             schema.TypeString,
+						Optional:         true,
+						ValidateFunc:     validation.StringIsJSON,
+						DiffSuppressFunc: SuppressEquivalentJSONDiffs,
+					},
+				},
+			},
+		},
+	}
+
+	for _, k := range dataScrubbingKeys {
+		if !authoritative {
+			s[k].Computed = true
+			continue
+		}
+		switch k {
+		case "data_scrubber", "data_scrubber_defaults":
+			s[k].Default = true
+		case "scrub_ip_addresses":
+			s[k].Default = false
+		}
+	}
+	return s
+}
+
+// dataScrubbing holds the data scrubbing settings of a project or an
+// organization, which go-sentry does not fully support.
+type dataScrubbing struct {
+	DataScrubber         *bool     `json:"dataScrubber,omitempty"`
+	DataScrubberDefaults *bool     `json:"dataScrubberDefaults,omitempty"`
+	ScrubIPAddresses     *bool     `json:"scrubIPAddresses,omitempty"`
+	SensitiveFields      *[]string `json:"sensitiveFields,omitempty"`
+	SafeFields           *[]string `json:"safeFields,omitempty"`
+	RelayPiiConfig       *string   `json:"relayPiiConfig,omitempty"`
+}
+
+// defaultDataScrubbing returns the settings of Sentry for new projects.
+func defaultDataScrubbing() *dataScrubbing {
+	return &dataScrubbing{
+		DataScrubber:         sentry.Bool(true),
+		DataScrubberDefaults: sentry.Bool(true),
+		ScrubIPAddresses:     sentry.Bool(false),
+		SensitiveFields:      &[]string{},
+		SafeFields:           &[]string{},
+		RelayPiiConfig:       sentry.String(""),
+	}
+}
+
+// relayPiiConfig is the advanced data scrubbing configuration, stored by
+// Sentry as a JSON string.
+type relayPiiConfig struct {
+	Rules        map[string]relayPiiRule `json:"rules"`
+	Applications map[string][]string     `json:"applications"`
+}
+
+type relayPiiRule struct {
+	Type      string            `json:"type"`
+	Pattern   string            `json:"pattern,omitempty"`
+	Redaction relayPiiRedaction `json:"redaction"`
+
+	// Extra holds the fields of the other rule types, such as keyPattern
+	// for redactPair rules.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r relayPiiRule) MarshalJSON() ([]byte, error) {
+	m := make(map[string]interface{}, len(r.Extra)+3)
+	for k, v := range r.Extra {
+		m[k] = v
+	}
+	m["type"] = r.Type
+	if r.Pattern != "" {
+		m["pattern"] = r.Pattern
+	}
+	m["redaction"] = r.Redaction
+	return json.Marshal(m)
+}
+
+func (r *relayPiiRule) UnmarshalJSON(b []byte) error {
+	type plain relayPiiRule
+	var rule plain
+	if err := json.Unmarshal(b, &rule); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, &rule.Extra); err != nil {
+		return err
+	}
+	for _, k := range relayPiiRuleFields {
+		delete(rule.Extra, k)
+	}
+	if len(rule.Extra) == 0 {
+		rule.Extra = nil
+	}
+	*r = relayPiiRule(rule)
+	return nil
+}
+
+// relayPiiRuleFields are the fields of relayPiiRule with an attribute.
+var relayPiiRuleFields = []string{"type", "pattern", "redaction"}
+
+type relayPiiRedaction struct {
+	Method string `json:"method"`
+	Text   string `json:"text,omitempty"`
+}
+
+// expandDataScrubbing returns the settings of the attributes for which
+// include returns true.
+func expandDataScrubbing(d *schema.ResourceData, include func(k string) bool) (*dataScrubbing, error) {
+	s := new(dataScrubbing)
+	if include("data_scrubber") {
+		s.DataScrubber = sentry.Bool(d.Get("data_scrubber").(bool))
+	}
+	if include("data_scrubber_defaults") {
+		s.DataScrubberDefaults = sentry.Bool(d.Get("data_scrubber_defaults").(bool))
+	}
+	if include("scrub_ip_addresses") {
+		s.ScrubIPAddresses = sentry.Bool(d.Get("scrub_ip_addresses").(bool))
+	}
+	if include("sensitive_fields") {
+		fields := expandStringList(d.Get("sensitive_fields").([]interface{}))
+		s.SensitiveFields = &fields
+	}
+	if include("safe_fields") {
+		fields := expandStringList(d.Get("safe_fields").([]interface{}))
+		s.SafeFields = &fields
+	}
+	if include("rule") {
+		config, err := expandRelayPiiConfig(d.Get("rule").([]interface{}))
+		if err != nil {
+			return nil, err
+		}
+		s.RelayPiiConfig = &config
+	}
+	return s, nil
+}
+
+func expandRelayPiiConfig(rules []interface{}) (string, error) {
+	if len(rules) == 0 {
+		return "", nil
+	}
+
+	config := relayPiiConfig{
+		Rules:        make(map[string]relayPiiRule, len(rules)),
+		Applications: make(map[string][]string),
+	}
+	for i, v := range rules {
+		m := v.(map[string]interface{})
+		if err := validateRelayPiiRule(m); err != nil {
+			return "", fmt.Errorf("rule %d: %w", i, err)
+		}
+		rule := relayPiiRule{
+			Type:      m["type"].(string),
+			Pattern:   m["pattern"].(string),
+			Redaction: relayPiiRedaction{Method: m["method"].(string)},
+		}
+		if rule.Redaction.Method == "replace" {
+			rule.Redaction.Text = m["replacement"].(string)
+		}
+		if extra := m["extra"].(string); extra != "" {
+			if err := json.Unmarshal([]byte(extra), &rule.Extra); err != nil {
+				return "", fmt.Errorf("rule %d: invalid extra: %w", i, err)
+			}
+		}
+
+		id := strconv.Itoa(i)
+		config.Rules[id] = rule
+		source := m["source"].(string)
+		config.Applications[source] = append(config.Applications[source], id)
+	}
+
+	b, err := json.Marshal(config)
+	return string(b), err
+}
+
+// validateRelayPiiRule checks the dependencies between the attributes of a
+// rule block.
+func validateRelayPiiRule(m map[string]interface{}) error {
+	ruleType, pattern := m["type"].(string), m["pattern"].(string)
+	if ruleType == "pattern" && pattern == "" {
+		return errors.New("pattern is required by pattern rules")
+	}
+	if ruleType != "pattern" && pattern != "" {
+		return errors.New("pattern is only used by pattern rules")
+	}
+	if m["method"].(string) != "replace" && m["replacement"].(string) != "" {
+		return errors.New("replacement is only used by the replace method")
+	}
+	if extra := m["extra"].(string); extra != "" {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(extra), &fields); err != nil {
+			return errors.New("extra must be a JSON object")
+		}
+		for _, k := range relayPiiRuleFields {
+			if _, ok := fields[k]; ok {
+				return fmt.Errorf("extra cannot set %s, which has its own attribute", k)
+			}
+		}
+	}
+	return nil
+}
+
+// customizeDiffDataScrubbingRules checks the rule blocks when planning,
+// skipping the ones with attributes not known yet.
+func customizeDiffDataScrubbingRules(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("rule") {
+		return nil
+	}
+	for i, v := range d.Get("rule").([]interface{}) {
+		known := true
+		for _, k := range []string{"type", "method", "pattern", "replacement", "extra"} {
+			known = known && d.NewValueKnown(fmt.Sprintf("rule.%d.%s", i, k))
+		}
+		if !known {
+			continue
+		}
+		if err := validateRelayPiiRule(v.(map[string]interface{})); err != nil {
+			return fmt.Errorf("rule %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// flattenDataScrubbing sets the data scrubbing attributes returned by
+// Sentry.
+func flattenDataScrubbing(d *schema.ResourceData, s *dataScrubbing) error {
+	var retErr *multierror.Error
+	if s.DataScrubber != nil {
+		retErr = multierror.Append(retErr, d.Set("data_scrubber", *s.DataScrubber))
+	}
+	if s.DataScrubberDefaults != nil {
+		retErr = multierror.Append(retErr, d.Set("data_scrubber_defaults", *s.DataScrubberDefaults))
+	}
+	if s.ScrubIPAddresses != nil {
+		retErr = multierror.Append(retErr, d.Set("scrub_ip_addresses", *s.ScrubIPAddresses))
+	}
+	if s.SensitiveFields != nil {
+		retErr = multierror.Append(retErr, d.Set("sensitive_fields", *s.SensitiveFields))
+	}
+	if s.SafeFields != nil {
+		retErr = multierror.Append(retErr, d.Set("safe_fields", *s.SafeFields))
+	}
+
+	rules, err := flattenRelayPiiConfig(sentry.StringValue(s.RelayPiiConfig))
+	if err != nil {
+		return err
+	}
+	retErr = multierror.Append(retErr, d.Set("rule", rules))
+	return retErr.ErrorOrNil()
+}
+
+// flattenRelayPiiConfig returns a rule block per rule and source, ordered
+// by rule ID and then by source.
+func flattenRelayPiiConfig(v string) ([]interface{}, error) {
+	rules := make([]interface{}, 0)
+	if v == "" {
+		return rules, nil
+	}
+
+	var config relayPiiConfig
+	if err := json.Unmarshal([]byte(v), &config); err != nil {
+		return nil, fmt.Errorf("invalid relayPiiConfig: %w", err)
+	}
+
+	sources := make(map[string][]string)
+	for source, ids := range config.Applications {
+		for _, id := range ids {
+			sources[id] = append(sources[id], source)
+		}
+	}
+	ids := make([]string, 0, len(config.Rules))
+	for id := range config.Rules {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, errA := strconv.Atoi(ids[i])
+		b, errB := strconv.Atoi(ids[j])
+		if errA == nil && errB == nil {
+			return a < b
+		}
+		return ids[i] < ids[j]
+	})
+
+	for _, id := range ids {
+		rule := config.Rules[id]
+		sort.Strings(sources[id])
+		extra := ""
+		if rule.Extra != nil {
+			b, err := json.Marshal(rule.Extra)
+			if err != nil {
+				return nil, err
+			}
+			extra = string(b)
+		}
+		for _, source := range sources[id] {
+			rules = append(rules, map[string]interface{}{
+				"type":        rule.Type,
+				"method":      rule.Redaction.Method,
+				"source":      source,
+				"pattern":     rule.Pattern,
+				"replacement": rule.Redaction.Text,
+				"extra":       extra,
+			})
+		}
+	}
+	return rules, nil
+}
@@ -16,9 +16,10 @@ func dataSourceSentryDashboard() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"organization": {
-				Description: "The slug of the organization the dashboard belongs to.",
+				Description: "The slug of the organization the dashboard belongs to. Defaults to the provider `organization`.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 			},
 			"internal_id": {
 				Description: "The internal ID for this dashboard.",
@@ -148,9 +149,12 @@ func dataSourceSentryDashboard() *schema.Resource {
 }
 
 func dataSourceSentryDashboardRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
-	org := d.Get("organization").(string)
+	org, err := getOrganization(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	dashboardID := d.Get("internal_id").(string)
 
 	tflog.Debug(ctx, "Reading dashboard", map[string]interface{}{
@@ -159,7 +163,7 @@ func dataSourceSentryDashboardRead(ctx context.Context, d *schema.ResourceData,
 	})
 	dashboard, _, err := client.Dashboards.Get(ctx, org, dashboardID)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIErr(err, nil, nil)
 	}
 
 	d.SetId(buildTwoPartID(org, sentry.StringValue(dashboard.ID)))
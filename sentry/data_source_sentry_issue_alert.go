@@ -20,9 +20,10 @@ func dataSourceSentryIssueAlertSentryIssueAlert() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"organization": {
-				Description: "The slug of the organization the issue alert belongs to.",
+				Description: "The slug of the organization the issue alert belongs to. Defaults to the provider `organization`.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 			},
 			"project": {
 				Description: "The slug of the project the issue alert belongs to.",
@@ -88,16 +89,19 @@ func dataSourceSentryIssueAlertSentryIssueAlert() *schema.Resource {
 }
 
 func dataSourceSentryIssueAlertRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
-	org := d.Get("organization").(string)
+	org, err := getOrganization(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	project := d.Get("project").(string)
 	alertID := d.Get("internal_id").(string)
 
 	tflog.Debug(ctx, "Reading issue alert", map[string]interface{}{"org": org, "project": project, "alertID": alertID})
 	alert, _, err := client.IssueAlerts.Get(ctx, org, project, alertID)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIErr(err, nil, nil)
 	}
 
 	conditions := make([]interface{}, 0, len(alert.Conditions))
@@ -8,7 +8,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/jianyuan/go-sentry/v2/sentry"
 )
 
 func dataSourceSentryKey() *schema.Resource {
@@ -19,9 +18,10 @@ func dataSourceSentryKey() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"organization": {
-				Description: "The slug of the organization the key should be created for.",
+				Description: "The slug of the organization the key should be created for. Defaults to the provider `organization`.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
 			},
 			"project": {
@@ -91,33 +91,64 @@ func dataSourceSentryKey() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"browser_sdk_version": {
+				Description: "The version of the browser SDK the JavaScript Loader Script of the key loads.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"dynamic_sdk_loader_options": {
+				Description: "The features of the browser SDK the JavaScript Loader Script of the key enables.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"has_performance": {
+							Description: "Whether performance monitoring is enabled.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"has_replay": {
+							Description: "Whether session replay is enabled.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"has_debug": {
+							Description: "Whether the debug logs of the SDK are enabled.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"loader_script_url": {
+				Description: "The URL of the JavaScript Loader Script of the key.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 		},
 	}
 }
 
 func dataSourceSentryKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
-	org := d.Get("organization").(string)
+	org, err := getOrganization(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	project := d.Get("project").(string)
+	if err := d.Set("organization", org); err != nil {
+		return diag.FromErr(err)
+	}
 
 	tflog.Debug(ctx, "Reading Sentry project keys", map[string]interface{}{
 		"org":     org,
 		"project": project,
 	})
 
-	listParams := &sentry.ListCursorParams{}
-	var allKeys []*sentry.ProjectKey
-	for {
-		keys, resp, err := client.ProjectKeys.List(ctx, org, project, listParams)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		allKeys = append(allKeys, keys...)
-		if resp.Cursor == "" {
-			break
-		}
-		listParams.Cursor = resp.Cursor
+	allKeys, err := listProjectKeys(ctx, client, org, project)
+	if err != nil {
+		return diagFromAPIErr(err, nil, nil)
 	}
 
 	if v, ok := d.GetOk("name"); ok {
@@ -160,7 +191,7 @@ func dataSourceSentryKeyRead(ctx context.Context, d *schema.ResourceData, meta i
 		len(allKeys))
 }
 
-func sentryKeyAttributes(d *schema.ResourceData, key *sentry.ProjectKey) error {
+func sentryKeyAttributes(d *schema.ResourceData, key *projectKey) error {
 	d.SetId(key.ID)
 	retErr := multierror.Append(
 		d.Set("name", key.Name),
@@ -171,6 +202,9 @@ func sentryKeyAttributes(d *schema.ResourceData, key *sentry.ProjectKey) error {
 		d.Set("dsn_secret", key.DSN.Secret),
 		d.Set("dsn_public", key.DSN.Public),
 		d.Set("dsn_csp", key.DSN.CSP),
+		d.Set("browser_sdk_version", key.BrowserSdkVersion),
+		d.Set("dynamic_sdk_loader_options", flattenProjectKeyLoaderOptions(key.DynamicSdkLoaderOptions)),
+		d.Set("loader_script_url", key.DSN.CDN),
 	)
 	if key.RateLimit != nil {
 		retErr = multierror.Append(
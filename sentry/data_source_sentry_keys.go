@@ -0,0 +1,184 @@
+package sentry
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceSentryKeys() *schema.Resource {
+	return &schema.Resource{
+		Description: "Sentry Keys data source. It lists the client keys of a project, oldest first.",
+
+		ReadContext: dataSourceSentryKeysRead,
+
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Description: "The slug of the organization the project belongs to. Defaults to the provider `organization`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"project": {
+				Description: "The slug of the project to list the keys of.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"name_regex": {
+				Description:  "A regular expression the names of the keys must match, such as `^backend-`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"is_active": {
+				Description: "Only list the active keys when `true`, or the inactive keys when `false`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"keys": {
+				Description: "The keys of the project.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "The ID of the key.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "The name of the key.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"public": {
+							Description: "Public key portion of the client key.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"secret": {
+							Description: "Secret key portion of the client key.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"project_id": {
+							Description: "The ID of the project that the key belongs to.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"is_active": {
+							Description: "Flag indicating the key is active.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"rate_limit_window": {
+							Description: "Length of time that will be considered when checking the rate limit.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"rate_limit_count": {
+							Description: "Number of events that can be reported within the rate limit window.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"dsn_public": {
+							Description: "DSN for the key.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"dsn_csp": {
+							Description: "DSN for the Content Security Policy (CSP) for the key.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"loader_script_url": {
+							Description: "The URL of the JavaScript Loader Script of the key.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"date_created": {
+							Description: "When the key was created, in RFC 3339 format.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSentryKeysRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderData).ClientWithContext(ctx)
+
+	org, err := getOrganization(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	project := d.Get("project").(string)
+
+	tflog.Debug(ctx, "Reading Sentry project keys", map[string]interface{}{
+		"org":     org,
+		"project": project,
+	})
+	allKeys, err := listProjectKeys(ctx, client, org, project)
+	if err != nil {
+		return diagFromAPIErr(err, nil, nil)
+	}
+
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+	isActive, filterActive := d.GetOkExists("is_active")
+
+	sort.SliceStable(allKeys, func(i, j int) bool {
+		return allKeys[i].DateCreated.Before(allKeys[j].DateCreated)
+	})
+	keys := make([]interface{}, 0, len(allKeys))
+	for _, key := range allKeys {
+		if nameRegex != nil && !nameRegex.MatchString(key.Name) {
+			continue
+		}
+		if filterActive && key.IsActive != isActive.(bool) {
+			continue
+		}
+		keys = append(keys, flattenProjectKeySummary(key))
+	}
+
+	d.SetId(buildTwoPartID(org, project))
+	retErr := multierror.Append(
+		d.Set("organization", org),
+		d.Set("keys", keys),
+	)
+	return diag.FromErr(retErr.ErrorOrNil())
+}
+
+func flattenProjectKeySummary(key *projectKey) map[string]interface{} {
+	m := map[string]interface{}{
+		"id":                key.ID,
+		"name":              key.Name,
+		"public":            key.Public,
+		"secret":            key.Secret,
+		"project_id":        key.ProjectID,
+		"is_active":         key.IsActive,
+		"rate_limit_window": 0,
+		"rate_limit_count":  0,
+		"dsn_public":        key.DSN.Public,
+		"dsn_csp":           key.DSN.CSP,
+		"loader_script_url": key.DSN.CDN,
+		"date_created":      key.DateCreated.Format(time.RFC3339),
+	}
+	if key.RateLimit != nil {
+		m["rate_limit_window"] = key.RateLimit.Window
+		m["rate_limit_count"] = key.RateLimit.Count
+	}
+	return m
+}
@@ -0,0 +1,111 @@
+package sentry
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+func TestAccSentryKeysDataSource_basic(t *testing.T) {
+	teamName := acctest.RandomWithPrefix("tf-team")
+	projectName := acctest.RandomWithPrefix("tf-project")
+	keyName := acctest.RandomWithPrefix("tf-key")
+	dn := "data.sentry_keys.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSentryKeysDataSourceConfig(teamName, projectName, keyName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dn, "keys.#", "1"),
+					resource.TestCheckResourceAttrPair(dn, "keys.0.id", "sentry_key.test", "id"),
+					resource.TestCheckResourceAttr(dn, "keys.0.name", keyName),
+					resource.TestCheckResourceAttr(dn, "keys.0.is_active", "true"),
+					resource.TestMatchResourceAttr(dn, "keys.0.dsn_public", regexp.MustCompile(`^https://`)),
+					resource.TestCheckResourceAttrSet(dn, "keys.0.date_created"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSentryKeysDataSourceConfig(teamName, projectName, keyName string) string {
+	return testAccSentryProjectConfig_team(teamName, projectName) + fmt.Sprintf(`
+resource "sentry_key" "test" {
+	organization = sentry_project.test.organization
+	project      = sentry_project.test.id
+	name         = "%[1]s"
+}
+
+data "sentry_keys" "test" {
+	organization = sentry_key.test.organization
+	project      = sentry_key.test.project
+	name_regex   = "^%[1]s$"
+}
+	`, keyName)
+}
+
+func TestUnitSentryKeysDataSource(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+	srv.AddProject(testUnitOrganization, "team", "project")
+
+	ctx := context.Background()
+	client := p.Meta().(*ProviderData).Client
+	var ids []string
+	for _, name := range []string{"backend-api", "frontend", "backend-worker"} {
+		key, _, err := client.ProjectKeys.Create(ctx, testUnitOrganization, "project", &sentry.CreateProjectKeyParams{Name: name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, key.ID)
+	}
+	if _, err := updateProjectKey(ctx, client, testUnitOrganization, "project", ids[2], &projectKeyParams{IsActive: sentry.Bool(false)}); err != nil {
+		t.Fatal(err)
+	}
+
+	keyIDs := func(config map[string]interface{}) []string {
+		config["organization"] = testUnitOrganization
+		config["project"] = "project"
+		d := testUnitReadDataSource(t, p, "sentry_keys", config)
+		var got []string
+		for _, v := range d.Get("keys").([]interface{}) {
+			got = append(got, v.(map[string]interface{})["id"].(string))
+		}
+		return got
+	}
+
+	for name, tc := range map[string]struct {
+		config map[string]interface{}
+		want   []string
+	}{
+		"all":        {map[string]interface{}{}, ids},
+		"name_regex": {map[string]interface{}{"name_regex": "^backend-"}, []string{ids[0], ids[2]}},
+		"active":     {map[string]interface{}{"is_active": true}, ids[:2]},
+		"inactive":   {map[string]interface{}{"is_active": false}, ids[2:]},
+		"none":       {map[string]interface{}{"name_regex": "^mobile"}, nil},
+	} {
+		if got := keyIDs(tc.config); fmt.Sprint(got) != fmt.Sprint(tc.want) {
+			t.Errorf("%s: got keys %v, want %v", name, got, tc.want)
+		}
+	}
+
+	d := testUnitReadDataSource(t, p, "sentry_keys", map[string]interface{}{
+		"organization": testUnitOrganization,
+		"project":      "project",
+		"name_regex":   "^frontend$",
+	})
+	if got := d.Get("keys.0.name").(string); got != "frontend" {
+		t.Errorf("unexpected name: %s", got)
+	}
+	if d.Get("keys.0.dsn_public").(string) == "" || d.Get("keys.0.date_created").(string) == "" {
+		t.Errorf("DSN and creation date should be set")
+	}
+}
@@ -16,9 +16,10 @@ func dataSourceSentryMetricAlert() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"organization": {
-				Description: "The slug of the organization the metric alert belongs to.",
+				Description: "The slug of the organization the metric alert belongs to. Defaults to the provider `organization`.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 			},
 			"project": {
 				Description: "The slug of the project the metric alert belongs to.",
@@ -136,16 +137,19 @@ func dataSourceSentryMetricAlert() *schema.Resource {
 }
 
 func dataSourceSentryMetricAlertRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
-	org := d.Get("organization").(string)
+	org, err := getOrganization(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	project := d.Get("project").(string)
 	alertID := d.Get("internal_id").(string)
 
 	tflog.Debug(ctx, "Reading metric alert", map[string]interface{}{"org": org, "project": project, "alertID": alertID})
 	alert, _, err := client.MetricAlerts.Get(ctx, org, project, alertID)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIErr(err, nil, nil)
 	}
 
 	d.SetId(buildThreePartID(org, project, sentry.StringValue(alert.ID)))
@@ -37,14 +37,14 @@ func dataSourceSentryOrganization() *schema.Resource {
 }
 
 func dataSourceSentryOrganizationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	org := d.Get("slug").(string)
 
 	tflog.Debug(ctx, "Reading organization", map[string]interface{}{"org": org})
 	organization, _, err := client.Organizations.Get(ctx, org)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIErr(err, nil, nil)
 	}
 
 	d.SetId(sentry.StringValue(organization.Slug))
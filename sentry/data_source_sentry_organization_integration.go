@@ -18,9 +18,10 @@ func dataSourceSentryOrganizationIntegration() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"organization": {
-				Description: "The slug of the organization the integration belongs to.",
+				Description: "The slug of the organization the integration belongs to. Defaults to the provider `organization`.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 			},
 			"provider_key": {
 				Description: "The key of the organization integration provider.",
@@ -42,9 +43,12 @@ func dataSourceSentryOrganizationIntegration() *schema.Resource {
 }
 
 func dataSourceSentryOrganizationIntegrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
-	org := d.Get("organization").(string)
+	org, err := getOrganization(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	providerKey := d.Get("provider_key").(string)
 	integrationName := d.Get("name").(string)
 
@@ -59,7 +63,7 @@ func dataSourceSentryOrganizationIntegrationRead(ctx context.Context, d *schema.
 	for {
 		keys, resp, err := client.OrganizationIntegrations.List(ctx, org, params)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromAPIErr(err, nil, nil)
 		}
 		orgIntegrations = append(orgIntegrations, keys...)
 
@@ -75,6 +79,7 @@ func dataSourceSentryOrganizationIntegrationRead(ctx context.Context, d *schema.
 		if orgIntegration.Name == integrationName {
 			d.SetId(orgIntegration.ID)
 			retErr := multierror.Append(
+				d.Set("organization", org),
 				d.Set("internal_id", orgIntegration.ID),
 			)
 			return diag.FromErr(retErr.ErrorOrNil())
@@ -18,9 +18,10 @@ func dataSourceSentryTeam() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"organization": {
-				Description: "The slug of the organization the team should be created for.",
+				Description: "The slug of the organization the team should be created for. Defaults to the provider `organization`.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 			},
 			"slug": {
 				Description: "The unique URL slug for this team.",
@@ -54,9 +55,12 @@ func dataSourceSentryTeam() *schema.Resource {
 }
 
 func dataSourceSentryTeamRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
-	org := d.Get("organization").(string)
+	org, err := getOrganization(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	teamSlug := d.Get("slug").(string)
 
 	tflog.Debug(ctx, "Reading team", map[string]interface{}{
@@ -65,7 +69,7 @@ func dataSourceSentryTeamRead(ctx context.Context, d *schema.ResourceData, meta
 	})
 	team, _, err := client.Teams.Get(ctx, org, teamSlug)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIErr(err, nil, nil)
 	}
 
 	d.SetId(sentry.StringValue(team.Slug))
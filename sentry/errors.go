@@ -0,0 +1,373 @@
+package sentry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+// nonFieldErrorKeys hold errors that apply to the payload as a whole, or to
+// the object they are nested in.
+var nonFieldErrorKeys = map[string]bool{
+	"detail":           true,
+	"nonFieldErrors":   true,
+	"non_field_errors": true,
+	"__all__":          true,
+}
+
+// apiErrorClass classifies errors returned by the Sentry API.
+type apiErrorClass int
+
+const (
+	apiErrorOther apiErrorClass = iota
+	apiErrorNotFound
+	apiErrorGone
+	apiErrorForbidden
+	apiErrorRateLimited
+	apiErrorConflict
+	apiErrorServer
+)
+
+// classifyAPIError returns the class of err, and the response it came with
+// if any.
+func classifyAPIError(err error) (apiErrorClass, *http.Response) {
+	var resp *http.Response
+	var rateLimitErr *sentry.RateLimitError
+	var errResp *sentry.ErrorResponse
+	switch {
+	case errors.As(err, &rateLimitErr):
+		return apiErrorRateLimited, rateLimitErr.Response
+	case errors.As(err, &errResp):
+		resp = errResp.Response
+	}
+	if resp == nil {
+		return apiErrorOther, nil
+	}
+
+	switch code := resp.StatusCode; {
+	case code == http.StatusNotFound:
+		return apiErrorNotFound, resp
+	case code == http.StatusGone:
+		return apiErrorGone, resp
+	case code == http.StatusForbidden:
+		return apiErrorForbidden, resp
+	case code == http.StatusTooManyRequests:
+		return apiErrorRateLimited, resp
+	case code == http.StatusConflict:
+		return apiErrorConflict, resp
+	case code >= 500:
+		return apiErrorServer, resp
+	}
+	return apiErrorOther, resp
+}
+
+// isNotFoundError reports whether err means the requested object does not
+// exist, or no longer does.
+func isNotFoundError(err error) bool {
+	class, _ := classifyAPIError(err)
+	return class == apiErrorNotFound || class == apiErrorGone
+}
+
+// checkClientGet reports whether the object read by a request that returned
+// err exists. Objects that are not found, for instance because they were
+// deleted outside of Terraform, are removed from state so that they are
+// planned to be created again. Other errors are returned as diagnostics.
+func checkClientGet(ctx context.Context, err error, d *schema.ResourceData) (bool, diag.Diagnostics) {
+	if err == nil {
+		return true, nil
+	}
+	if isNotFoundError(err) {
+		tflog.Warn(ctx, "Sentry object not found, removing from state", map[string]interface{}{
+			"id": d.Id(),
+		})
+		d.SetId("")
+		return false, nil
+	}
+	return false, diagFromAPIErr(err, nil, nil)
+}
+
+// checkClientDelete returns the diagnostics of a delete request that
+// returned err. Deleting an object that no longer exists succeeds.
+func checkClientDelete(ctx context.Context, err error, d *schema.ResourceData) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+	if isNotFoundError(err) {
+		tflog.Debug(ctx, "Sentry object already deleted", map[string]interface{}{
+			"id": d.Id(),
+		})
+		return nil
+	}
+	return diagFromAPIErr(err, nil, nil)
+}
+
+// diagFromAPIErr converts an error returned by the Sentry API into
+// diagnostics. Validation errors, which Sentry keys by the fields of the
+// request payload, become one diagnostic per message, with an AttributePath
+// pointing at the matching attribute of r. Payload fields are matched to
+// attributes by converting them to snake case, or through fields for those
+// that are named differently, such as "triggers" for the "trigger" block.
+// r may be nil for requests without a payload.
+//
+// Permission errors name the token scope the request requires, requests
+// rejected by the provider explain why, and other classes of errors explain
+// what can be done about them.
+func diagFromAPIErr(err error, r *schema.Resource, fields map[string]string) diag.Diagnostics {
+	var deniedErr *writeDeniedError
+	if errors.As(err, &deniedErr) {
+		return diag.Diagnostics{deniedErr.diagnostic()}
+	}
+
+	class, resp := classifyAPIError(err)
+	switch class {
+	case apiErrorForbidden:
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Permission denied by Sentry",
+			Detail:   forbiddenDetail(err, resp.Request),
+		}}
+	case apiErrorRateLimited:
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  err.Error(),
+			Detail:   "Sentry kept rate limiting the request after all retries. Lower the provider max_concurrent_requests or raise max_retries.",
+		}}
+	case apiErrorConflict:
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  err.Error(),
+			Detail:   "The object conflicts with an existing one, such as one with the same slug. Import the existing object or choose another slug.",
+		}}
+	}
+
+	if r == nil {
+		return diag.FromErr(err)
+	}
+	body, ok := validationErrorBody(err)
+	if !ok {
+		return diag.FromErr(err)
+	}
+
+	t := validationErrorTranslator{fields: fields}
+	diags := t.translate(body, cty.Path{}, nil, r.Schema, "")
+	if len(diags) == 0 {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// forbiddenDetail explains a 403 Forbidden error of req.
+func forbiddenDetail(err error, req *http.Request) string {
+	scope := tokenScope(req)
+	if scope == "" {
+		return fmt.Sprintf("%s. Make sure the auth token has the scopes this request requires, and that its user has access to the object.", err)
+	}
+	return fmt.Sprintf("%s. This request requires an auth token with the %q scope. Make sure the token has it, and that its user has access to the object.", err, scope)
+}
+
+// scopeSet holds the token scopes required to read, write and delete a kind
+// of object.
+type scopeSet struct {
+	read, write, delete string
+}
+
+var (
+	organizationScopes = scopeSet{"org:read", "org:write", "org:admin"}
+	memberScopes       = scopeSet{"member:read", "member:write", "member:admin"}
+	teamScopes         = scopeSet{"team:read", "team:write", "team:admin"}
+	projectScopes      = scopeSet{"project:read", "project:write", "project:admin"}
+	alertScopes        = scopeSet{"alerts:read", "alerts:write", "alerts:write"}
+	dashboardScopes    = scopeSet{"org:read", "org:write", "org:write"}
+	integrationScopes  = scopeSet{"org:read", "org:integrations", "org:integrations"}
+)
+
+// tokenScope returns the token scope Sentry requires for req, or an empty
+// string for endpoints it does not know about.
+func tokenScope(req *http.Request) string {
+	if req == nil || req.URL == nil {
+		return ""
+	}
+
+	// Paths look like /api/0/{root}/{organization}[/{slug}]/{sub-resource}/...
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	var root string
+	var rest []string
+segments:
+	for i, segment := range segments {
+		switch segment {
+		case "organizations":
+			root, rest = segment, segments[minInt(i+2, len(segments)):]
+			break segments
+		case "teams", "projects":
+			root, rest = segment, segments[minInt(i+3, len(segments)):]
+			break segments
+		}
+	}
+
+	var sub string
+	if len(rest) > 0 {
+		sub = rest[0]
+	}
+
+	var scopes scopeSet
+	switch {
+	case root == "":
+		return ""
+	case sub == "alert-rules" || sub == "rules":
+		scopes = alertScopes
+	case root == "organizations" && sub == "members":
+		scopes = memberScopes
+	case root == "organizations" && sub == "teams":
+		scopes = teamScopes
+	case root == "organizations" && sub == "dashboards":
+		scopes = dashboardScopes
+	case root == "organizations" && (sub == "repos" || sub == "code-mappings" || sub == "integrations"):
+		scopes = integrationScopes
+	case root == "organizations":
+		scopes = organizationScopes
+	case root == "teams" && sub == "projects":
+		scopes = projectScopes
+	case root == "teams":
+		scopes = teamScopes
+	default:
+		scopes = projectScopes
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		return scopes.read
+	case http.MethodDelete:
+		return scopes.delete
+	default:
+		return scopes.write
+	}
+}
+
+// validationErrorBody returns the decoded body of a 400 Bad Request response
+// if it holds field-keyed errors.
+func validationErrorBody(err error) (map[string]interface{}, bool) {
+	var errResp *sentry.ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response == nil || errResp.Response.StatusCode != http.StatusBadRequest || errResp.Response.Body == nil {
+		return nil, false
+	}
+
+	data, readErr := io.ReadAll(errResp.Response.Body)
+	errResp.Response.Body = io.NopCloser(bytes.NewReader(data))
+	if readErr != nil {
+		return nil, false
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, false
+	}
+	for k := range body {
+		if !nonFieldErrorKeys[k] {
+			return body, true
+		}
+	}
+	return nil, false
+}
+
+type validationErrorTranslator struct {
+	fields map[string]string
+}
+
+// translate walks a value of an error body found at path. attr is the schema
+// of the attribute at path, if known, and block the schema of the object
+// whose fields the keys of a nested object refer to. Messages for fields that
+// do not match any attribute are attached to the closest known attribute and
+// prefixed with the field name.
+func (t validationErrorTranslator) translate(v interface{}, path cty.Path, attr *schema.Schema, block map[string]*schema.Schema, prefix string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	switch v := v.(type) {
+	case string:
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Invalid value rejected by Sentry",
+			Detail:        prefix + v,
+			AttributePath: path,
+		})
+	case []interface{}:
+		for i, item := range v {
+			switch item := item.(type) {
+			case string:
+				diags = append(diags, t.translate(item, path, attr, block, prefix)...)
+			default:
+				// Errors of list elements are aligned with the list, with
+				// empty objects for valid elements.
+				itemPath := path
+				if attr != nil && attr.Type == schema.TypeList {
+					itemPath = path.IndexInt(i)
+				}
+				diags = append(diags, t.translate(item, itemPath, nil, block, prefix)...)
+			}
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			if nonFieldErrorKeys[k] {
+				diags = append(diags, t.translate(v[k], path, attr, block, prefix)...)
+				continue
+			}
+
+			name := t.attributeName(k)
+			child, ok := block[name]
+			if !ok {
+				diags = append(diags, t.translate(v[k], path, nil, nil, prefix+k+": ")...)
+				continue
+			}
+
+			childPath := path.GetAttr(name)
+			var childBlock map[string]*schema.Schema
+			if elem, ok := child.Elem.(*schema.Resource); ok {
+				childBlock = elem.Schema
+				if _, isObject := v[k].(map[string]interface{}); isObject && child.Type == schema.TypeList {
+					// Blocks limited to a single element are sent as objects.
+					childPath = childPath.IndexInt(0)
+				}
+			}
+			diags = append(diags, t.translate(v[k], childPath, child, childBlock, "")...)
+		}
+	}
+
+	return diags
+}
+
+// attributeName returns the attribute name of a payload field.
+func (t validationErrorTranslator) attributeName(field string) string {
+	if name, ok := t.fields[field]; ok {
+		return name
+	}
+
+	var b strings.Builder
+	for i, r := range field {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
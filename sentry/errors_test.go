@@ -0,0 +1,293 @@
+package sentry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+func testErrorResponse(status int, body string) error {
+	return &sentry.ErrorResponse{
+		Response: &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    &http.Request{Method: http.MethodPost},
+		},
+		Detail: body,
+	}
+}
+
+func TestDiagFromAPIErr(t *testing.T) {
+	type want struct {
+		path   cty.Path
+		detail string
+	}
+
+	testCases := []struct {
+		name     string
+		err      error
+		resource func() diag.Diagnostics
+		want     []want
+	}{
+		{
+			name: "nested list",
+			err:  testErrorResponse(http.StatusBadRequest, `{"triggers":[{},{"alertThreshold":["This field is required."]}]}`),
+			want: []want{
+				{cty.GetAttrPath("trigger").IndexInt(1).GetAttr("alert_threshold"), "This field is required."},
+			},
+		},
+		{
+			name: "multiple fields",
+			err:  testErrorResponse(http.StatusBadRequest, `{"name":["Too long."],"timeWindow":["Invalid.","Must be positive."]}`),
+			want: []want{
+				{cty.GetAttrPath("name"), "Too long."},
+				{cty.GetAttrPath("time_window"), "Invalid."},
+				{cty.GetAttrPath("time_window"), "Must be positive."},
+			},
+		},
+		{
+			name: "non field errors",
+			err:  testErrorResponse(http.StatusBadRequest, `{"nonFieldErrors":["Must include at least one trigger"],"name":["Required."]}`),
+			want: []want{
+				{cty.GetAttrPath("name"), "Required."},
+				{cty.Path{}, "Must include at least one trigger"},
+			},
+		},
+		{
+			name: "unknown field",
+			err:  testErrorResponse(http.StatusBadRequest, `{"triggers":[{"unknownField":["Nope."]}]}`),
+			want: []want{
+				{cty.GetAttrPath("trigger").IndexInt(0), "unknownField: Nope."},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := diagFromAPIErr(tc.err, resourceSentryMetricAlert(), metricAlertAPIFields)
+
+			var got []want
+			for _, d := range diags {
+				if d.Severity != diag.Error {
+					t.Errorf("unexpected severity %v", d.Severity)
+				}
+				got = append(got, want{d.AttributePath, d.Detail})
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDiagFromAPIErr_issueAlert(t *testing.T) {
+	err := testErrorResponse(http.StatusBadRequest, `{"conditions":[{},{"interval":["Invalid interval."]}]}`)
+
+	diags := diagFromAPIErr(err, resourceSentryIssueAlert(), issueAlertAPIFields)
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %#v", diags)
+	}
+	if want := cty.GetAttrPath("conditions").IndexInt(1); !diags[0].AttributePath.Equals(want) {
+		t.Errorf("got path %#v, want %#v", diags[0].AttributePath, want)
+	}
+	if want := "interval: Invalid interval."; diags[0].Detail != want {
+		t.Errorf("got detail %q, want %q", diags[0].Detail, want)
+	}
+}
+
+func TestDiagFromAPIErr_singleBlock(t *testing.T) {
+	err := testErrorResponse(http.StatusBadRequest, `{"widgets":[{"layout":{"minH":["Ensure this value is greater than or equal to 1."]}}]}`)
+
+	diags := diagFromAPIErr(err, resourceSentryDashboard(), dashboardAPIFields)
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %#v", diags)
+	}
+	if want := cty.GetAttrPath("widget").IndexInt(0).GetAttr("layout").IndexInt(0).GetAttr("min_h"); !diags[0].AttributePath.Equals(want) {
+		t.Errorf("got path %#v, want %#v", diags[0].AttributePath, want)
+	}
+}
+
+func TestDiagFromAPIErr_fallback(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+	}{
+		{"not an API error", errors.New("connection refused")},
+		{"detail only", testErrorResponse(http.StatusBadRequest, `{"detail":"Invalid request"}`)},
+		{"not JSON", testErrorResponse(http.StatusBadRequest, `<html></html>`)},
+		{"server error", testErrorResponse(http.StatusInternalServerError, `{"name":["Oops."]}`)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := diagFromAPIErr(tc.err, resourceSentryMetricAlert(), metricAlertAPIFields)
+			if len(diags) != 1 || diags[0].Summary != tc.err.Error() || diags[0].AttributePath != nil {
+				t.Errorf("expected the error as is, got %#v", diags)
+			}
+		})
+	}
+}
+
+func TestUnitSentryMetricAlert_validationError(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+	srv.AddProject(testUnitOrganization, "team", "project")
+	r := newTestUnitResource(t, p, "sentry_metric_alert")
+
+	trigger := func(label string) map[string]interface{} {
+		return map[string]interface{}{
+			"action":            []interface{}{},
+			"alert_threshold":   1000.0,
+			"label":             label,
+			"resolve_threshold": 100.0,
+			"threshold_type":    0,
+		}
+	}
+
+	diags := r.expectApplyError(map[string]interface{}{
+		"organization":      testUnitOrganization,
+		"project":           "project",
+		"name":              "tf-metric-alert",
+		"dataset":           "transactions",
+		"query":             "",
+		"aggregate":         "p50(transaction.duration)",
+		"time_window":       50.0,
+		"threshold_type":    0,
+		"resolve_threshold": 100.0,
+		"trigger":           []interface{}{trigger("critical"), trigger("major")},
+	})
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %#v", diags)
+	}
+	if want := cty.GetAttrPath("trigger").IndexInt(1).GetAttr("label"); !diags[0].AttributePath.Equals(want) {
+		t.Errorf("got path %#v, want %#v", diags[0].AttributePath, want)
+	}
+}
+
+func TestClassifyAPIError(t *testing.T) {
+	testCases := []struct {
+		err  error
+		want apiErrorClass
+	}{
+		{errors.New("connection refused"), apiErrorOther},
+		{testErrorResponse(http.StatusBadRequest, `{}`), apiErrorOther},
+		{testErrorResponse(http.StatusNotFound, `{"detail":"Not found"}`), apiErrorNotFound},
+		{testErrorResponse(http.StatusGone, `{}`), apiErrorGone},
+		{testErrorResponse(http.StatusForbidden, `{}`), apiErrorForbidden},
+		{testErrorResponse(http.StatusTooManyRequests, `{}`), apiErrorRateLimited},
+		{&sentry.RateLimitError{Response: &http.Response{StatusCode: http.StatusTooManyRequests}}, apiErrorRateLimited},
+		{testErrorResponse(http.StatusConflict, `{}`), apiErrorConflict},
+		{testErrorResponse(http.StatusBadGateway, `{}`), apiErrorServer},
+	}
+	for _, tc := range testCases {
+		if got, _ := classifyAPIError(tc.err); got != tc.want {
+			t.Errorf("classifyAPIError(%v): got %v, want %v", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestTokenScope(t *testing.T) {
+	testCases := []struct {
+		method string
+		path   string
+		want   string
+	}{
+		{http.MethodGet, "/api/0/organizations/org/", "org:read"},
+		{http.MethodPut, "/api/0/organizations/org/", "org:write"},
+		{http.MethodDelete, "/api/0/organizations/org/", "org:admin"},
+		{http.MethodPost, "/api/0/organizations/org/teams/", "team:write"},
+		{http.MethodDelete, "/api/0/teams/org/team/", "team:admin"},
+		{http.MethodPost, "/api/0/teams/org/team/projects/", "project:write"},
+		{http.MethodGet, "/api/0/projects/org/project/", "project:read"},
+		{http.MethodDelete, "/api/0/projects/org/project/keys/abc/", "project:admin"},
+		{http.MethodPost, "/api/0/projects/org/project/teams/team/", "project:write"},
+		{http.MethodPut, "/api/0/projects/org/project/rules/1/", "alerts:write"},
+		{http.MethodDelete, "/api/0/projects/org/project/alert-rules/1/", "alerts:write"},
+		{http.MethodGet, "/api/0/organizations/org/alert-rules/1/", "alerts:read"},
+		{http.MethodPut, "/api/0/organizations/org/members/1/", "member:write"},
+		{http.MethodDelete, "/api/0/organizations/org/dashboards/1/", "org:write"},
+		{http.MethodPost, "/api/0/organizations/org/repos/", "org:integrations"},
+		{http.MethodGet, "/api/0/organizations/org/code-mappings/", "org:read"},
+		{http.MethodGet, "/sentry/api/0/projects/org/project/", "project:read"},
+		{http.MethodGet, "/api/0/users/me/", ""},
+	}
+	for _, tc := range testCases {
+		req, err := http.NewRequest(tc.method, "https://sentry.example.com"+tc.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := tokenScope(req); got != tc.want {
+			t.Errorf("%s %s: got %q, want %q", tc.method, tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestUnitErrors_notFound(t *testing.T) {
+	testCases := []struct {
+		resource string
+		config   map[string]interface{}
+	}{
+		{
+			resource: "sentry_team",
+			config: map[string]interface{}{
+				"organization": testUnitOrganization,
+				"name":         "tf-team",
+				"slug":         "tf-team",
+			},
+		},
+		{
+			resource: "sentry_project",
+			config: map[string]interface{}{
+				"organization": testUnitOrganization,
+				"teams":        []interface{}{"team"},
+				"name":         "tf-project",
+				"slug":         "tf-project",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.resource, func(t *testing.T) {
+			p, srv := testUnitProvider(t)
+			srv.AddTeam(testUnitOrganization, "team")
+			r := newTestUnitResource(t, p, tc.resource)
+
+			r.apply(tc.config)
+			state := r.destroy()
+
+			// Deleted outside of Terraform, so it is dropped from state.
+			r.expectGone(state)
+
+			// Deleting it again succeeds.
+			r.state = state
+			r.destroy()
+		})
+	}
+}
+
+func TestUnitErrors_forbidden(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+	r := newTestUnitResource(t, p, "sentry_project")
+
+	r.apply(map[string]interface{}{
+		"organization": testUnitOrganization,
+		"teams":        []interface{}{"team"},
+		"name":         "tf-project",
+		"slug":         "tf-project",
+	})
+
+	srv.FailNext(1, http.StatusForbidden)
+	_, diags := r.resource.RefreshWithoutUpgrade(context.Background(), r.state, p.Meta())
+	if len(diags) != 1 || diags[0].Summary != "Permission denied by Sentry" {
+		t.Fatalf("expected a permission diagnostic, got %#v", diags)
+	}
+	if want := `"project:read" scope`; !strings.Contains(diags[0].Detail, want) {
+		t.Errorf("expected detail to contain %q, got %q", want, diags[0].Detail)
+	}
+}
@@ -1,14 +1,16 @@
 package sentry
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"net/http"
 	"reflect"
 	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/jianyuan/go-sentry/v2/sentry"
 )
 
 func buildTwoPartID(a, b string) string {
@@ -40,6 +42,12 @@ func splitSentryAlertID(id string) (org string, project string, alertID string,
 	return
 }
 
+// SuppressAfterCreate suppresses diffs of attributes only used when a
+// resource is created.
+func SuppressAfterCreate(k, old, new string, d *schema.ResourceData) bool {
+	return d.Id() != ""
+}
+
 func SuppressEquivalentJSONDiffs(k, old, new string, d *schema.ResourceData) bool {
 	var o interface{}
 	if err := json.Unmarshal([]byte(old), &o); err != nil {
@@ -74,9 +82,14 @@ func followShape(shape, value interface{}) interface{} {
 			return nil
 		}
 
-		v := make([]interface{}, 0, len(shape))
-		for i, shapeValue := range shape {
-			v = append(v, followShape(shapeValue, value[i]))
+		// Elements without a shape, such as the ones added outside of
+		// Terraform, are kept whole so that they show as a diff.
+		v := make([]interface{}, 0, len(value))
+		for i, elem := range value {
+			if i < len(shape) {
+				elem = followShape(shape[i], elem)
+			}
+			v = append(v, elem)
 		}
 		return v
 	default:
@@ -102,20 +115,130 @@ func expandStringList(configured []interface{}) []string {
 	return vs
 }
 
-// checkClientGet returns a `found` bool and an `error` to indicate if a Get request was successful.
-// The following return values are meaningful:
-// `true`, `nil` => a resource was successfully found
-// `false`, `nil` => a resource was successfully not found
-// `false`, `err` => encountered an unexpected error
-func checkClientGet(resp *sentry.Response, err error, d *schema.ResourceData) (bool, error) {
+// operationFunc is the signature of the create, read, update and delete
+// functions of a resource.
+type operationFunc = func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+
+// wrapOperations replaces each operation implemented by r with the result of
+// wrap, which is given the name of the operation as used in timeouts.
+func wrapOperations(r *schema.Resource, wrap func(op string, f operationFunc) operationFunc) {
+	if r.CreateContext != nil {
+		r.CreateContext = wrap(schema.TimeoutCreate, r.CreateContext)
+	}
+	if r.ReadContext != nil {
+		r.ReadContext = wrap(schema.TimeoutRead, r.ReadContext)
+	}
+	if r.UpdateContext != nil {
+		r.UpdateContext = wrap(schema.TimeoutUpdate, r.UpdateContext)
+	}
+	if r.DeleteContext != nil {
+		r.DeleteContext = wrap(schema.TimeoutDelete, r.DeleteContext)
+	}
+}
+
+// resourceGetter is implemented by both *schema.ResourceData and
+// *schema.ResourceDiff.
+type resourceGetter interface {
+	GetOk(key string) (interface{}, bool)
+}
+
+// getOrganization returns the organization set on d, falling back to the
+// provider's default organization.
+func getOrganization(d resourceGetter, meta interface{}) (string, error) {
+	if org, ok := d.GetOk("organization"); ok {
+		return org.(string), nil
+	}
+	if org := meta.(*ProviderData).Organization; org != "" {
+		return org, nil
+	}
+	return "", errors.New("organization must be set on the resource or the provider")
+}
+
+// customizeDiffOrganization plans the provider's default organization for
+// resources created without an organization of their own.
+func customizeDiffOrganization(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" {
+		return nil
+	}
+	if raw := d.GetRawConfig(); !raw.IsNull() && !raw.GetAttr("organization").IsNull() {
+		// Set in the configuration, possibly to a value not yet known.
+		return nil
+	}
+	if _, ok := d.GetOk("organization"); ok {
+		return nil
+	}
+
+	org, err := getOrganization(d, meta)
 	if err != nil {
-		if resp != nil && resp.Response.StatusCode == http.StatusNotFound {
-			d.SetId("")
-			return false, nil
+		return err
+	}
+	return d.SetNew("organization", org)
+}
+
+// validateRules returns a validation function checking each line of a set of
+// rules with validate, reporting errors with their line number.
+func validateRules(summary string, validate func(line string) error) schema.SchemaValidateDiagFunc {
+	return func(i interface{}, path cty.Path) diag.Diagnostics {
+		var diags diag.Diagnostics
+		for n, line := range strings.Split(i.(string), "\n") {
+			if err := validate(line); err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       summary,
+					Detail:        fmt.Sprintf("Line %d: %s", n+1, err),
+					AttributePath: path,
+				})
+			}
 		}
+		return diags
+	}
+}
 
-		return false, err
+// suppressEquivalentRules suppresses the diff of rules equal once
+// normalized.
+func suppressEquivalentRules(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeRules(old) == normalizeRules(new)
+}
+
+// normalizeRules trims each rule, separates fields by a single space and
+// drops empty lines.
+func normalizeRules(raw string) string {
+	var lines []string
+	for _, line := range strings.Split(raw, "\n") {
+		if fields := ruleFields(line); len(fields) > 0 {
+			if strings.HasPrefix(fields[0], "#") {
+				// Comments are kept as written.
+				lines = append(lines, strings.TrimSpace(line))
+				continue
+			}
+			lines = append(lines, strings.Join(fields, " "))
+		}
 	}
+	return strings.Join(lines, "\n")
+}
 
-	return true, nil
+// ruleFields splits a rule on whitespace, keeping quoted values such as
+// path:"My Documents/*" whole.
+func ruleFields(line string) []string {
+	var fields []string
+	var field strings.Builder
+	quoted := false
+	for _, r := range line {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			field.WriteRune(r)
+		case !quoted && (r == ' ' || r == '\t' || r == '\r'):
+			if field.Len() > 0 {
+				fields = append(fields, field.String())
+				field.Reset()
+			}
+		default:
+			field.WriteRune(r)
+		}
+	}
+	if field.Len() > 0 {
+		fields = append(fields, field.String())
+	}
+	return fields
 }
@@ -92,6 +92,55 @@ func TestFollowShape(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "value is longer than shape",
+			shape: []interface{}{
+				map[string]interface{}{
+					"a": "",
+				},
+			},
+			value: []interface{}{
+				map[string]interface{}{
+					"a": "a",
+					"b": "b",
+				},
+				map[string]interface{}{
+					"a": "a",
+					"b": "b",
+				},
+			},
+			want: []interface{}{
+				map[string]interface{}{
+					"a": "a",
+				},
+				map[string]interface{}{
+					"a": "a",
+					"b": "b",
+				},
+			},
+		},
+		{
+			name: "value is shorter than shape",
+			shape: []interface{}{
+				map[string]interface{}{
+					"a": "",
+				},
+				map[string]interface{}{
+					"b": "",
+				},
+			},
+			value: []interface{}{
+				map[string]interface{}{
+					"a": "a",
+					"b": "b",
+				},
+			},
+			want: []interface{}{
+				map[string]interface{}{
+					"a": "a",
+				},
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -2,13 +2,14 @@ package sentry
 
 import (
 	"context"
+	"strings"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func importOrganizationAndID(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	org, id, err := splitTwoPartID(d.Id(), "organization-slug", "id")
+	org, id, err := splitTwoPartID(withDefaultOrganization(d.Id(), 2, meta), "organization-slug", "id")
 	if err != nil {
 		return nil, err
 	}
@@ -22,8 +23,21 @@ func importOrganizationAndID(ctx context.Context, d *schema.ResourceData, meta i
 	return []*schema.ResourceData{d}, nil
 }
 
+// importSentryProject imports a project, setting the attributes Sentry does
+// not return to their defaults so that they do not show up as changes.
+func importSentryProject(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	retErr := multierror.Append(
+		d.Set("default_rules", true),
+		d.Set("ignore_team_membership", false),
+	)
+	if err := retErr.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+	return importOrganizationAndID(ctx, d, meta)
+}
+
 func importOrganizationProjectAndID(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	org, project, id, err := splitThreePartID(d.Id(), "organization-slug", "project-slug", "id")
+	org, project, id, err := splitThreePartID(withDefaultOrganization(d.Id(), 3, meta), "organization-slug", "project-slug", "id")
 	if err != nil {
 		return nil, err
 	}
@@ -39,3 +53,28 @@ func importOrganizationProjectAndID(ctx context.Context, d *schema.ResourceData,
 	}
 	return []*schema.ResourceData{d}, nil
 }
+
+// importOrganizationScopedID imports resources whose ID is in the
+// organization-slug/id format, keeping the full ID.
+func importOrganizationScopedID(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.SetId(withDefaultOrganization(d.Id(), 2, meta))
+	return []*schema.ResourceData{d}, nil
+}
+
+// importOrganizationProjectScopedID imports resources whose ID is in the
+// organization-slug/project-slug/id format, keeping the full ID.
+func importOrganizationProjectScopedID(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.SetId(withDefaultOrganization(d.Id(), 3, meta))
+	return []*schema.ResourceData{d}, nil
+}
+
+// withDefaultOrganization prepends the provider's default organization to an
+// import ID of the given number of parts when the organization part has been
+// omitted.
+func withDefaultOrganization(id string, parts int, meta interface{}) string {
+	org := meta.(*ProviderData).Organization
+	if org == "" || strings.Count(id, "/") != parts-2 {
+		return id
+	}
+	return buildTwoPartID(org, id)
+}
@@ -0,0 +1,690 @@
+package sentry
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// issueAlertRuleClass is a condition, filter or action of issue alerts with
+// a typed block, such as `event_frequency` for
+// sentry.rules.conditions.event_frequency.EventFrequencyCondition.
+type issueAlertRuleClass struct {
+	block       string
+	id          string
+	description string
+	fields      map[string]issueAlertRuleField
+
+	// validate checks the dependencies between the attributes of the block,
+	// which may not be known when planning.
+	validate func(m map[string]interface{}) error
+}
+
+// issueAlertRuleField maps an attribute of a typed block to a field of the
+// rule payload.
+type issueAlertRuleField struct {
+	key    string
+	schema *schema.Schema
+
+	// values maps the attribute values to the field values, when they differ.
+	values map[string]string
+}
+
+var (
+	issueAlertFrequencyIntervals  = []string{"1m", "5m", "15m", "1h", "1d", "1w", "30d"}
+	issueAlertComparisonIntervals = []string{"5m", "15m", "1h", "1d", "1w", "30d"}
+	issueAlertMatchTypes          = []string{"eq", "ne", "sw", "ew", "co", "nc", "is", "ns"}
+)
+
+// issueAlertFrequencyFields returns the fields of the event frequency
+// conditions, whose value is an int or a float.
+func issueAlertFrequencyFields(valueType schema.ValueType, intervals []string) map[string]issueAlertRuleField {
+	return map[string]issueAlertRuleField{
+		"comparison_type": {key: "comparisonType", schema: &schema.Schema{
+			Description: "`count` to compare the number of events to `value`, or `percent` to compare the " +
+				"increase in percent over `comparison_interval`. Defaults to `count`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "count",
+			ValidateFunc: validation.StringInSlice([]string{"count", "percent"}, false),
+		}},
+		"value": {key: "value", schema: &schema.Schema{
+			Description: "The number of events or the percentage to exceed.",
+			Type:        valueType,
+			Required:    true,
+		}},
+		"interval": {key: "interval", schema: &schema.Schema{
+			Description:  fmt.Sprintf("The period to count the events over: %s.", quoteList(intervals)),
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice(intervals, false),
+		}},
+		"comparison_interval": {key: "comparisonInterval", schema: &schema.Schema{
+			Description: fmt.Sprintf("The earlier period to compare to when `comparison_type` is `percent`: %s.",
+				quoteList(issueAlertComparisonIntervals)),
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice(issueAlertComparisonIntervals, false),
+		}},
+	}
+}
+
+func validateIssueAlertFrequency(m map[string]interface{}) error {
+	if m["comparison_type"] == "percent" && m["comparison_interval"] == "" {
+		return fmt.Errorf("comparison_interval is required when comparison_type is percent")
+	}
+	return nil
+}
+
+// validateIssueAlertMatch checks that value is set unless the match is `is`
+// or `ns`, which check whether the attribute is set.
+func validateIssueAlertMatch(m map[string]interface{}) error {
+	if match := m["match"]; match != "is" && match != "ns" && m["value"] == "" {
+		return fmt.Errorf("value is required when match is %s", match)
+	}
+	return nil
+}
+
+// validateIssueAlertTarget checks that target_identifier is set when
+// notifying a team or a member.
+func validateIssueAlertTarget(m map[string]interface{}) error {
+	if target := m["target_type"]; (target == "Team" || target == "Member") && m["target_identifier"] == "" {
+		return fmt.Errorf("target_identifier is required when target_type is %s", target)
+	}
+	return nil
+}
+
+var issueAlertConditionClasses = []*issueAlertRuleClass{
+	{
+		block:       "first_seen_event",
+		id:          "sentry.rules.conditions.first_seen_event.FirstSeenEventCondition",
+		description: "A new issue is created.",
+	},
+	{
+		block:       "regression_event",
+		id:          "sentry.rules.conditions.regression_event.RegressionEventCondition",
+		description: "The issue changes state from resolved to unresolved.",
+	},
+	{
+		block:       "reappeared_event",
+		id:          "sentry.rules.conditions.reappeared_event.ReappearedEventCondition",
+		description: "The issue changes state from ignored to unresolved.",
+	},
+	{
+		block:       "event_frequency",
+		id:          "sentry.rules.conditions.event_frequency.EventFrequencyCondition",
+		description: "The issue is seen more than `value` times in `interval`.",
+		fields:      issueAlertFrequencyFields(schema.TypeInt, issueAlertFrequencyIntervals),
+		validate:    validateIssueAlertFrequency,
+	},
+	{
+		block:       "event_unique_user_frequency",
+		id:          "sentry.rules.conditions.event_frequency.EventUniqueUserFrequencyCondition",
+		description: "The issue is seen by more than `value` users in `interval`.",
+		fields:      issueAlertFrequencyFields(schema.TypeInt, issueAlertFrequencyIntervals),
+		validate:    validateIssueAlertFrequency,
+	},
+	{
+		block:       "event_frequency_percent",
+		id:          "sentry.rules.conditions.event_frequency.EventFrequencyPercentCondition",
+		description: "The issue affects more than `value` percent of sessions in `interval`.",
+		fields:      issueAlertFrequencyFields(schema.TypeFloat, []string{"5m", "10m", "30m", "1h"}),
+		validate:    validateIssueAlertFrequency,
+	},
+}
+
+var issueAlertFilterClasses = []*issueAlertRuleClass{
+	{
+		block:       "age_comparison",
+		id:          "sentry.rules.filters.age_comparison.AgeComparisonFilter",
+		description: "The issue is older or newer than `value` `time`.",
+		fields: map[string]issueAlertRuleField{
+			"comparison_type": {key: "comparison_type", schema: &schema.Schema{
+				Description:  "`older` or `newer`.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"older", "newer"}, false),
+			}},
+			"value": {key: "value", schema: &schema.Schema{
+				Description:  "The age of the issue, in `time` units.",
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			}},
+			"time": {key: "time", schema: &schema.Schema{
+				Description:  "The unit of `value`: `minute`, `hour`, `day` or `week`.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"minute", "hour", "day", "week"}, false),
+			}},
+		},
+	},
+	{
+		block:       "issue_occurrences",
+		id:          "sentry.rules.filters.issue_occurrences.IssueOccurrencesFilter",
+		description: "The issue has happened at least `value` times.",
+		fields: map[string]issueAlertRuleField{
+			"value": {key: "value", schema: &schema.Schema{
+				Description:  "The number of events of the issue.",
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			}},
+		},
+	},
+	{
+		block:       "assigned_to",
+		id:          "sentry.rules.filters.assigned_to.AssignedToFilter",
+		description: "The issue is assigned to no one, a team or a member.",
+		fields: map[string]issueAlertRuleField{
+			"target_type": {key: "targetType", schema: &schema.Schema{
+				Description:  "`Unassigned`, `Team` or `Member`.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"Unassigned", "Team", "Member"}, false),
+			}},
+			"target_identifier": {key: "targetIdentifier", schema: &schema.Schema{
+				Description: "The ID of the team or the member.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			}},
+		},
+		validate: validateIssueAlertTarget,
+	},
+	{
+		block:       "latest_release",
+		id:          "sentry.rules.filters.latest_release.LatestReleaseFilter",
+		description: "The event is from the latest release.",
+	},
+	{
+		block:       "event_attribute",
+		id:          "sentry.rules.filters.event_attribute.EventAttributeFilter",
+		description: "An attribute of the event matches `value`.",
+		fields: map[string]issueAlertRuleField{
+			"attribute": {key: "attribute", schema: &schema.Schema{
+				Description:  "The attribute of the event, such as `message`, `platform` or `exception.type`.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			}},
+			"match": {key: "match", schema: &schema.Schema{
+				Description:  fmt.Sprintf("How the attribute is compared to `value`: %s.", quoteList(issueAlertMatchTypes)),
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(issueAlertMatchTypes, false),
+			}},
+			"value": {key: "value", schema: &schema.Schema{
+				Description: "The value to compare the attribute to. Not used by the `is` and `ns` matches.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			}},
+		},
+		validate: validateIssueAlertMatch,
+	},
+	{
+		block:       "tagged_event",
+		id:          "sentry.rules.filters.tagged_event.TaggedEventFilter",
+		description: "A tag of the event matches `value`.",
+		fields: map[string]issueAlertRuleField{
+			"key": {key: "key", schema: &schema.Schema{
+				Description:  "The key of the tag.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			}},
+			"match": {key: "match", schema: &schema.Schema{
+				Description:  fmt.Sprintf("How the tag is compared to `value`: %s.", quoteList(issueAlertMatchTypes)),
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(issueAlertMatchTypes, false),
+			}},
+			"value": {key: "value", schema: &schema.Schema{
+				Description: "The value to compare the tag to. Not used by the `is` and `ns` matches.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			}},
+		},
+		validate: validateIssueAlertMatch,
+	},
+	{
+		block:       "level",
+		id:          "sentry.rules.filters.level.LevelFilter",
+		description: "The level of the event compares to `level`.",
+		fields: map[string]issueAlertRuleField{
+			"match": {key: "match", schema: &schema.Schema{
+				Description:  "`eq`, `gte` or `lte`.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"eq", "gte", "lte"}, false),
+			}},
+			"level": {
+				key: "level",
+				schema: &schema.Schema{
+					Description: "`sample`, `debug`, `info`, `warning`, `error` or `fatal`.",
+					Type:        schema.TypeString,
+					Required:    true,
+					ValidateFunc: validation.StringInSlice([]string{
+						"sample", "debug", "info", "warning", "error", "fatal",
+					}, false),
+				},
+				values: map[string]string{
+					"sample":  "0",
+					"debug":   "10",
+					"info":    "20",
+					"warning": "30",
+					"error":   "40",
+					"fatal":   "50",
+				},
+			},
+		},
+	},
+}
+
+var issueAlertActionClasses = []*issueAlertRuleClass{
+	{
+		block:       "notify_email",
+		id:          "sentry.mail.actions.NotifyEmailAction",
+		description: "Send an email to the owners of the issue, a team or a member.",
+		fields: map[string]issueAlertRuleField{
+			"target_type": {key: "targetType", schema: &schema.Schema{
+				Description:  "`IssueOwners`, `Team` or `Member`.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"IssueOwners", "Team", "Member"}, false),
+			}},
+			"target_identifier": {key: "targetIdentifier", schema: &schema.Schema{
+				Description: "The ID of the team or the member.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			}},
+			"fallthrough_type": {key: "fallthroughType", schema: &schema.Schema{
+				Description: "Who to notify when the issue has no owners: `AllMembers`, `ActiveMembers` or " +
+					"`NoOne`. Only used with `IssueOwners`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"AllMembers", "ActiveMembers", "NoOne"}, false),
+			}},
+		},
+		validate: validateIssueAlertTarget,
+	},
+	{
+		block:       "notify_event",
+		id:          "sentry.rules.actions.notify_event.NotifyEventAction",
+		description: "Send a notification to all the legacy integrations.",
+	},
+	{
+		block:       "notify_event_service",
+		id:          "sentry.rules.actions.notify_event_service.NotifyEventServiceAction",
+		description: "Send a notification with a plugin or an internal integration.",
+		fields: map[string]issueAlertRuleField{
+			"service": {key: "service", schema: &schema.Schema{
+				Description:  "The slug of the plugin or the internal integration, such as `webhooks`.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			}},
+		},
+	},
+	{
+		block:       "slack",
+		id:          "sentry.integrations.slack.notify_action.SlackNotifyServiceAction",
+		description: "Send a Slack notification.",
+		fields: map[string]issueAlertRuleField{
+			"workspace": {key: "workspace", schema: &schema.Schema{
+				Description:  "The ID of the Slack integration, such as `sentry_organization_integration.slack.internal_id`.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			}},
+			"channel": {key: "channel", schema: &schema.Schema{
+				Description:  "The name of the channel or the user, such as `#alerts` or `@jane`.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			}},
+			"channel_id": {key: "channel_id", schema: &schema.Schema{
+				Description: "The ID of the channel or the user, which Sentry looks up from `channel` when unset.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			}},
+			"tags": {key: "tags", schema: &schema.Schema{
+				Description: "The tags of the event to show in the notification.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			}},
+			"notes": {key: "notes", schema: &schema.Schema{
+				Description: "The text to add to the notification.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			}},
+		},
+	},
+	{
+		block:       "pagerduty",
+		id:          "sentry.integrations.pagerduty.notify_action.PagerDutyNotifyServiceAction",
+		description: "Send a PagerDuty notification.",
+		fields: map[string]issueAlertRuleField{
+			"account": {key: "account", schema: &schema.Schema{
+				Description:  "The ID of the PagerDuty integration.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			}},
+			"service": {key: "service", schema: &schema.Schema{
+				Description:  "The ID of the PagerDuty service.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			}},
+			"severity": {key: "severity", schema: &schema.Schema{
+				Description: "The severity of the incident: `default`, `critical`, `warning`, `error` or `info`. " +
+					"Defaults to `default`, which depends on the level of the event.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "default",
+				ValidateFunc: validation.StringInSlice([]string{"default", "critical", "warning", "error", "info"}, false),
+			}},
+		},
+	},
+	{
+		block:       "opsgenie",
+		id:          "sentry.integrations.opsgenie.notify_action.OpsgenieNotifyTeamAction",
+		description: "Send an Opsgenie notification.",
+		fields: map[string]issueAlertRuleField{
+			"account": {key: "account", schema: &schema.Schema{
+				Description:  "The ID of the Opsgenie integration.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			}},
+			"team": {key: "team", schema: &schema.Schema{
+				Description:  "The ID of the Opsgenie team.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			}},
+			"priority": {key: "priority", schema: &schema.Schema{
+				Description:  "The priority of the alert, from `P1` to `P5`. Defaults to `P3`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "P3",
+				ValidateFunc: validation.StringInSlice([]string{"P1", "P2", "P3", "P4", "P5"}, false),
+			}},
+		},
+	},
+	{
+		block:       "sentry_app",
+		id:          "sentry.rules.actions.notify_event_sentry_app.NotifyEventSentryAppAction",
+		description: "Send a notification with a Sentry app.",
+		fields: map[string]issueAlertRuleField{
+			"sentry_app_installation_uuid": {key: "sentryAppInstallationUuid", schema: &schema.Schema{
+				Description:  "The UUID of the installation of the Sentry app.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			}},
+			"settings": {key: "settings", schema: &schema.Schema{
+				Description: "The settings of the alert rule action of the Sentry app, by name.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			}},
+		},
+	},
+}
+
+// issueAlertRuleBlockSchema returns the schema of the typed blocks of a kind
+// of rules, each element having exactly one of classes.
+func issueAlertRuleBlockSchema(description string, classes []*issueAlertRuleClass) *schema.Schema {
+	elem := make(map[string]*schema.Schema, len(classes))
+	for _, class := range classes {
+		fields := make(map[string]*schema.Schema, len(class.fields))
+		for attr, field := range class.fields {
+			fields[attr] = field.schema
+		}
+		elem[class.block] = &schema.Schema{
+			Description: fmt.Sprintf("%s Class `%s`.", class.description, class.id),
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: fields,
+			},
+		}
+	}
+	return &schema.Schema{
+		Description: description,
+		Type:        schema.TypeList,
+		Optional:    true,
+		Elem: &schema.Resource{
+			Schema: elem,
+		},
+	}
+}
+
+// issueAlertRuleBlockClass returns the class of a typed block, which must
+// have exactly one class.
+func issueAlertRuleBlockClass(block map[string]interface{}, classes []*issueAlertRuleClass) (*issueAlertRuleClass, map[string]interface{}, error) {
+	var found *issueAlertRuleClass
+	var attrs map[string]interface{}
+	names := make([]string, 0, len(classes))
+	for _, class := range classes {
+		names = append(names, class.block)
+		v, ok := block[class.block].([]interface{})
+		if !ok || len(v) == 0 {
+			continue
+		}
+		if found != nil {
+			return nil, nil, fmt.Errorf("only one of %s and %s can be set", found.block, class.block)
+		}
+		found = class
+		// Blocks without attributes are null.
+		attrs, _ = v[0].(map[string]interface{})
+	}
+	if found == nil {
+		return nil, nil, fmt.Errorf("one of %s must be set", strings.Join(names, ", "))
+	}
+	if attrs == nil {
+		attrs = make(map[string]interface{})
+	}
+	return found, attrs, nil
+}
+
+// validateIssueAlertRuleBlocks checks that each typed block has exactly one
+// class.
+func validateIssueAlertRuleBlocks(kind string, blocks []interface{}, classes []*issueAlertRuleClass) error {
+	for i, v := range blocks {
+		block, _ := v.(map[string]interface{})
+		if _, _, err := issueAlertRuleBlockClass(block, classes); err != nil {
+			return fmt.Errorf("%s.%d: %w", kind, i, err)
+		}
+	}
+	return nil
+}
+
+// expandIssueAlertRuleBlocks returns the rule payload of typed blocks.
+func expandIssueAlertRuleBlocks(kind string, blocks []interface{}, classes []*issueAlertRuleClass) ([]map[string]interface{}, error) {
+	rules := make([]map[string]interface{}, 0, len(blocks))
+	for i, v := range blocks {
+		block, _ := v.(map[string]interface{})
+		class, attrs, err := issueAlertRuleBlockClass(block, classes)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%d: %w", kind, i, err)
+		}
+		if class.validate != nil {
+			if err := class.validate(attrs); err != nil {
+				return nil, fmt.Errorf("%s.%d.%s: %w", kind, i, class.block, err)
+			}
+		}
+
+		rule := map[string]interface{}{"id": class.id}
+		for attr, field := range class.fields {
+			if value, ok := expandIssueAlertRuleField(field, attrs[attr]); ok {
+				rule[field.key] = value
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func expandIssueAlertRuleField(field issueAlertRuleField, v interface{}) (interface{}, bool) {
+	switch field.schema.Type {
+	case schema.TypeString:
+		s, _ := v.(string)
+		if s == "" {
+			return nil, false
+		}
+		if value, ok := field.values[s]; ok {
+			return value, true
+		}
+		return s, true
+	case schema.TypeList:
+		tags := expandStringList(v.([]interface{}))
+		if len(tags) == 0 {
+			return nil, false
+		}
+		return strings.Join(tags, ","), true
+	case schema.TypeMap:
+		m, _ := v.(map[string]interface{})
+		if len(m) == 0 {
+			return nil, false
+		}
+		names := make([]string, 0, len(m))
+		for name := range m {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		settings := make([]interface{}, 0, len(m))
+		for _, name := range names {
+			settings = append(settings, map[string]interface{}{"name": name, "value": m[name]})
+		}
+		return settings, true
+	default:
+		return v, true
+	}
+}
+
+// issueAlertRuleClassByID returns the class of a rule payload, or nil if it
+// has no typed block.
+func issueAlertRuleClassByID(rule map[string]interface{}, classes []*issueAlertRuleClass) *issueAlertRuleClass {
+	id, _ := rule["id"].(string)
+	for _, class := range classes {
+		if class.id == id {
+			return class
+		}
+	}
+	return nil
+}
+
+// flattenIssueAlertRuleBlocks returns the typed blocks of rules, which must
+// all have a class.
+func flattenIssueAlertRuleBlocks(rules []map[string]interface{}, classes []*issueAlertRuleClass) []interface{} {
+	blocks := make([]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		class := issueAlertRuleClassByID(rule, classes)
+		attrs := make(map[string]interface{}, len(class.fields))
+		for attr, field := range class.fields {
+			attrs[attr] = flattenIssueAlertRuleField(field, rule[field.key])
+		}
+		blocks = append(blocks, map[string]interface{}{
+			class.block: []interface{}{attrs},
+		})
+	}
+	return blocks
+}
+
+func flattenIssueAlertRuleField(field issueAlertRuleField, v interface{}) interface{} {
+	if v == nil && field.schema.Default != nil {
+		return field.schema.Default
+	}
+
+	switch field.schema.Type {
+	case schema.TypeInt:
+		f, _ := issueAlertRuleNumber(v)
+		return int(f)
+	case schema.TypeFloat:
+		f, _ := issueAlertRuleNumber(v)
+		return f
+	case schema.TypeList:
+		s, _ := v.(string)
+		tags := make([]interface{}, 0)
+		for _, tag := range strings.Split(s, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+		return tags
+	case schema.TypeMap:
+		settings := make(map[string]interface{})
+		list, _ := v.([]interface{})
+		for _, setting := range list {
+			if m, ok := setting.(map[string]interface{}); ok {
+				settings[fmt.Sprint(m["name"])] = issueAlertRuleString(m["value"])
+			}
+		}
+		return settings
+	default:
+		s := issueAlertRuleString(v)
+		for attrValue, value := range field.values {
+			if value == s {
+				return attrValue
+			}
+		}
+		return s
+	}
+}
+
+// issueAlertRuleNumber returns a number Sentry may return as a string.
+func issueAlertRuleNumber(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// issueAlertRuleString returns a string Sentry may return as a number, such
+// as the ID of an integration.
+func issueAlertRuleString(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// quoteList returns values as a list of code spans, such as "`a`, `b` or `c`".
+func quoteList(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, "`"+v+"`")
+	}
+	if len(quoted) < 2 {
+		return strings.Join(quoted, "")
+	}
+	return strings.Join(quoted[:len(quoted)-1], ", ") + " or " + quoted[len(quoted)-1]
+}
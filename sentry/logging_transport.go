@@ -0,0 +1,264 @@
+package sentry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// redacted replaces secrets in logs.
+	redacted = "[REDACTED]"
+
+	// maxLoggedBodySize caps the size of each logged body.
+	maxLoggedBodySize = 64 * 1024
+)
+
+// loggedRateLimitHeaders are the rate limit headers included in logs.
+var loggedRateLimitHeaders = []string{
+	"X-Sentry-Rate-Limit-Limit",
+	"X-Sentry-Rate-Limit-Remaining",
+	"X-Sentry-Rate-Limit-Reset",
+	headerConcurrentLimit,
+	headerConcurrentRemaining,
+	"Retry-After",
+}
+
+// attemptKey is the context key of the attempt counter of a request.
+type attemptKey struct{}
+
+// attemptCountingTransport numbers the attempts of each request, so that
+// loggingTransport, which sees every attempt, can tell retries apart.
+type attemptCountingTransport struct {
+	Delegate http.RoundTripper
+}
+
+func (t *attemptCountingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := context.WithValue(req.Context(), attemptKey{}, new(int32))
+	return t.Delegate.RoundTrip(req.WithContext(ctx))
+}
+
+// loggingTransport logs every attempt of a request at TRACE level, and
+// optionally to a file, with secrets redacted.
+type loggingTransport struct {
+	Delegate http.RoundTripper
+
+	// LogBodies includes request and response bodies in logs.
+	LogBodies bool
+	// File, if set, receives a JSON line per attempt.
+	File io.Writer
+
+	fileMu sync.Mutex
+}
+
+// newLoggingTransport returns a loggingTransport that also appends to the
+// file at path, if set.
+func newLoggingTransport(delegate http.RoundTripper, logBodies bool, path string) (*loggingTransport, error) {
+	t := &loggingTransport{Delegate: delegate, LogBodies: logBodies}
+	if path != "" {
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, err
+		}
+		t.File = f
+	}
+	return t, nil
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	fields := map[string]interface{}{
+		"method": req.Method,
+		"path":   req.URL.Path,
+	}
+	if req.URL.RawQuery != "" {
+		fields["query"] = req.URL.RawQuery
+	}
+	if counter, ok := ctx.Value(attemptKey{}).(*int32); ok {
+		fields["attempt"] = atomic.AddInt32(counter, 1)
+	}
+	if auth := req.Header.Get("Authorization"); auth != "" {
+		fields["authorization"] = redactAuthorization(auth)
+	}
+
+	if t.LogBodies && req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(ctx)
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		fields["request_body"] = redactBody(req.URL.Path, body, true)
+	}
+
+	start := time.Now()
+	resp, err := t.Delegate.RoundTrip(req)
+	fields["latency_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		fields["error"] = err.Error()
+		t.log(ctx, fields)
+		return resp, err
+	}
+
+	fields["status"] = resp.StatusCode
+	rateLimit := map[string]string{}
+	for _, h := range loggedRateLimitHeaders {
+		if v := resp.Header.Get(h); v != "" {
+			rateLimit[h] = v
+		}
+	}
+	if len(rateLimit) > 0 {
+		fields["rate_limit"] = rateLimit
+	}
+
+	if t.LogBodies && resp.Body != nil {
+		body, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		if readErr != nil {
+			return resp, readErr
+		}
+		fields["response_body"] = redactBody(req.URL.Path, body, false)
+	}
+
+	t.log(ctx, fields)
+	return resp, nil
+}
+
+func (t *loggingTransport) log(ctx context.Context, fields map[string]interface{}) {
+	tflog.Trace(ctx, "Sentry API request", fields)
+
+	if t.File == nil {
+		return
+	}
+	entry := make(map[string]interface{}, len(fields)+1)
+	for k, v := range fields {
+		entry[k] = v
+	}
+	entry["time"] = time.Now().UTC().Format(time.RFC3339Nano)
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	t.fileMu.Lock()
+	defer t.fileMu.Unlock()
+	if _, err := t.File.Write(append(line, '\n')); err != nil {
+		tflog.Warn(ctx, "Could not write to the HTTP log file", map[string]interface{}{"error": err.Error()})
+	}
+}
+
+// redactAuthorization keeps the scheme of an Authorization header only.
+func redactAuthorization(v string) string {
+	if i := strings.IndexByte(v, ' '); i >= 0 {
+		return v[:i+1] + redacted
+	}
+	return redacted
+}
+
+// credentialsInURL matches the credentials of URLs such as DSNs.
+var credentialsInURL = regexp.MustCompile(`([a-zA-Z][a-zA-Z0-9+.-]*://)[^/@\s"]+@`)
+
+// redactBody returns the body of a request to path, or of its response, with
+// secrets redacted: key secrets and DSNs, values of fields that look like
+// credentials, plugin options, and credentials embedded in URLs.
+func redactBody(path string, body []byte, request bool) string {
+	// The whole body is redacted before it is truncated, as truncated JSON
+	// cannot be parsed.
+	s := redactWholeBody(path, body, request)
+	if len(s) > maxLoggedBodySize {
+		s = s[:maxLoggedBodySize] + "...(truncated)"
+	}
+	return s
+}
+
+func redactWholeBody(path string, body []byte, request bool) string {
+	var v interface{}
+	if json.Unmarshal(body, &v) != nil {
+		return credentialsInURL.ReplaceAllString(string(body), "${1}"+redacted+"@")
+	}
+
+	isPlugin := strings.Contains(path, "/plugins/")
+	if m, ok := v.(map[string]interface{}); ok && isPlugin && request {
+		// Plugin options are sent as a flat object, and any of them may be
+		// a credential.
+		for k := range m {
+			m[k] = redacted
+		}
+	}
+	v = redactValue(v, isPlugin)
+
+	out, err := json.Marshal(v)
+	if err != nil {
+		return redacted
+	}
+	return string(out)
+}
+
+// redactValue redacts v in place and returns it.
+func redactValue(v interface{}, isPlugin bool) interface{} {
+	switch v := v.(type) {
+	case string:
+		return credentialsInURL.ReplaceAllString(v, "${1}"+redacted+"@")
+	case []interface{}:
+		for i := range v {
+			v[i] = redactValue(v[i], isPlugin)
+		}
+	case map[string]interface{}:
+		for k, item := range v {
+			switch {
+			case isSensitiveKey(k):
+				v[k] = redacted
+			case k == "dsn":
+				v[k] = redactAll(item)
+			case isPlugin && k == "value":
+				// Values of plugin config entries.
+				v[k] = redacted
+			default:
+				v[k] = redactValue(item, isPlugin)
+			}
+		}
+	}
+	return v
+}
+
+// redactAll replaces every string in v.
+func redactAll(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		return redacted
+	case []interface{}:
+		for i := range v {
+			v[i] = redactAll(v[i])
+		}
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = redactAll(v[k])
+		}
+	}
+	return v
+}
+
+// isSensitiveKey reports whether a field holds a credential.
+func isSensitiveKey(k string) bool {
+	k = strings.ToLower(k)
+	for _, s := range []string{"secret", "token", "password", "api_key", "apikey", "credential"} {
+		if strings.Contains(k, s) {
+			return true
+		}
+	}
+	return false
+}
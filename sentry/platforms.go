@@ -0,0 +1,209 @@
+package sentry
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+//go:generate go run ../tools/platforms -o platforms.json
+
+// platformsJSON is the platform catalogue shipped with the provider, generated
+// from the Sentry documentation.
+//
+//go:embed platforms.json
+var platformsJSON []byte
+
+var embeddedPlatforms = mustParsePlatforms(platformsJSON)
+
+// platformSet is a catalogue of valid platform IDs.
+type platformSet map[string]struct{}
+
+// parsePlatforms parses a JSON array of platform IDs, or of objects with an
+// "id" field such as the ones the Sentry frontend uses.
+func parsePlatforms(data []byte) (platformSet, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("expected a JSON array of platforms: %w", err)
+	}
+
+	platforms := make(platformSet, len(raw))
+	for _, item := range raw {
+		var id string
+		if err := json.Unmarshal(item, &id); err != nil {
+			var obj struct {
+				ID string `json:"id"`
+			}
+			if err := json.Unmarshal(item, &obj); err != nil {
+				return nil, fmt.Errorf("unexpected platform %s", item)
+			}
+			id = obj.ID
+		}
+		if id == "" {
+			return nil, fmt.Errorf("unexpected platform %s", item)
+		}
+		platforms[id] = struct{}{}
+	}
+	return platforms, nil
+}
+
+func mustParsePlatforms(data []byte) platformSet {
+	platforms, err := parsePlatforms(data)
+	if err != nil {
+		panic(err)
+	}
+	return platforms
+}
+
+// union returns the platforms in either set.
+func (s platformSet) union(other platformSet) platformSet {
+	platforms := make(platformSet, len(s)+len(other))
+	for id := range s {
+		platforms[id] = struct{}{}
+	}
+	for id := range other {
+		platforms[id] = struct{}{}
+	}
+	return platforms
+}
+
+// check returns an error suggesting the closest platforms if v is not in the
+// catalogue.
+func (s platformSet) check(v string) error {
+	if _, ok := s[v]; ok {
+		return nil
+	}
+
+	msg := fmt.Sprintf("%s is not a known platform", v)
+	if suggestions := s.suggest(v, 3); len(suggestions) > 0 {
+		msg += fmt.Sprintf(", did you mean %s?", strings.Join(suggestions, ", "))
+	}
+	return errors.New(msg)
+}
+
+// suggest returns up to n platforms closest to v, if any are close enough to
+// be a likely typo.
+func (s platformSet) suggest(v string, n int) []string {
+	best := len(v) / 4
+	if best < 1 {
+		best = 1
+	}
+
+	var suggestions []string
+	for id := range s {
+		switch d := editDistance(v, id); {
+		case d < best:
+			best = d
+			suggestions = []string{id}
+		case d == best:
+			suggestions = append(suggestions, id)
+		}
+	}
+	sort.Strings(suggestions)
+	if len(suggestions) > n {
+		suggestions = suggestions[:n]
+	}
+	return suggestions
+}
+
+// editDistance returns the number of insertions, deletions, substitutions and
+// transpositions of adjacent characters needed to turn a into b.
+func editDistance(a, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = minInt(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = minInt(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
+
+func minInt(v int, vs ...int) int {
+	for _, w := range vs {
+		if w < v {
+			v = w
+		}
+	}
+	return v
+}
+
+// platforms returns the platform catalogue, extended with the list served at
+// PlatformsURL if set. The list is fetched once; on failure, the embedded
+// catalogue is used on its own.
+func (p *ProviderData) platforms(ctx context.Context) platformSet {
+	if p.PlatformsURL == "" {
+		return embeddedPlatforms
+	}
+
+	p.platformsOnce.Do(func() {
+		p.serverPlatforms = embeddedPlatforms
+
+		client := p.ClientWithContext(ctx)
+		req, err := client.NewRequest(http.MethodGet, p.PlatformsURL, nil)
+		if err != nil {
+			tflog.Warn(ctx, "Invalid platforms URL, using the embedded platform catalogue", map[string]interface{}{"error": err.Error()})
+			return
+		}
+		var raw json.RawMessage
+		if _, err := client.Do(ctx, req, &raw); err != nil {
+			tflog.Warn(ctx, "Could not fetch platforms from the server, using the embedded platform catalogue", map[string]interface{}{"error": err.Error()})
+			return
+		}
+		platforms, err := parsePlatforms(raw)
+		if err != nil {
+			tflog.Warn(ctx, "Could not parse platforms from the server, using the embedded platform catalogue", map[string]interface{}{"error": err.Error()})
+			return
+		}
+		p.serverPlatforms = embeddedPlatforms.union(platforms)
+	})
+	return p.serverPlatforms
+}
+
+// platformWarnings warns when a platform is not in the catalogue, which may
+// include platforms only known to the server. Sentry has platforms the
+// catalogue misses, so unknown platforms are left for the server to accept or
+// reject.
+func platformWarnings(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.HasChange("platform") {
+		return nil
+	}
+	platform := d.Get("platform").(string)
+	if platform == "" {
+		return nil
+	}
+
+	if err := meta.(*ProviderData).platforms(ctx).check(platform); err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "Unknown platform",
+			Detail: fmt.Sprintf("%s. It may be misspelled, or missing from the catalogue of the provider; set "+
+				"platforms_url to also accept the platforms of the Sentry server.", err),
+			AttributePath: cty.GetAttrPath("platform"),
+		}}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package sentry
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPlatformSet_check(t *testing.T) {
+	tests := []struct {
+		platform string
+		want     string
+	}{
+		{"python-django", ""},
+		{"pyhton", "pyhton is not a known platform, did you mean python?"},
+		{"javascript-reactt", "javascript-reactt is not a known platform, did you mean javascript-react?"},
+		{"go-gim", "go-gim is not a known platform, did you mean go-gin?"},
+		{"node-expres", "node-expres is not a known platform, did you mean node-express?"},
+		{"cobol", "cobol is not a known platform"},
+	}
+	for _, tt := range tests {
+		err := embeddedPlatforms.check(tt.platform)
+		var got string
+		if err != nil {
+			got = err.Error()
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.platform, got, tt.want)
+		}
+	}
+}
+
+func TestParsePlatforms(t *testing.T) {
+	platforms, err := parsePlatforms([]byte(`["python", {"id": "zig", "name": "Zig"}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(platforms) != 2 || platforms.check("python") != nil || platforms.check("zig") != nil {
+		t.Errorf("unexpected platforms %v", platforms)
+	}
+
+	for _, data := range []string{`{}`, `[1]`, `[{"name": "Zig"}]`} {
+		if _, err := parsePlatforms([]byte(data)); err == nil {
+			t.Errorf("%s: expected an error", data)
+		}
+	}
+}
+
+func TestUnitSentryProject_platform(t *testing.T) {
+	var authorization string
+	platforms := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id": "zig"}]`))
+	}))
+	defer platforms.Close()
+
+	base := map[string]interface{}{
+		"organization": testUnitOrganization,
+		"teams":        []interface{}{"team"},
+		"name":         "tf-project",
+	}
+
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+	r := newTestUnitResource(t, p, "sentry_project").withConfig(base)
+	// Unknown platforms are only warned about, as the catalogue may miss
+	// platforms Sentry accepts.
+	diags := r.apply(map[string]interface{}{"platform": "pyhton"})
+	if len(diags) != 1 || diags[0].Summary != "Unknown platform" || !strings.Contains(diags[0].Detail, "did you mean python?") {
+		t.Errorf("expected an unknown platform warning, got %#v", diags)
+	}
+	r.expectNoChanges(map[string]interface{}{"platform": "pyhton"})
+	if diags := r.apply(map[string]interface{}{"platform": "javascript-nuxt"}); len(diags) != 0 {
+		t.Errorf("expected no warnings, got %#v", diags)
+	}
+	diags = r.apply(map[string]interface{}{"platform": "zig"})
+	if len(diags) != 1 || !strings.Contains(diags[0].Detail, "zig is not a known platform") {
+		t.Errorf("expected an unknown platform warning, got %#v", diags)
+	}
+
+	p, srv = testUnitProviderWithConfig(t, map[string]interface{}{
+		"platforms_url": platforms.URL,
+	})
+	srv.AddTeam(testUnitOrganization, "team")
+	r = newTestUnitResource(t, p, "sentry_project").withConfig(base)
+	if diags := r.apply(map[string]interface{}{"platform": "zig"}); len(diags) != 0 {
+		t.Errorf("expected no warnings, got %#v", diags)
+	}
+	r.checkAttrs(map[string]string{
+		"platform": "zig",
+	})
+	if authorization != "Bearer test-token" {
+		t.Errorf("platforms were fetched with authorization %q", authorization)
+	}
+}
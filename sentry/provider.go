@@ -2,9 +2,16 @@ package sentry
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func init() {
@@ -34,6 +41,98 @@ func NewProvider(version string) func() *schema.Provider {
 					Optional:    true,
 					DefaultFunc: schema.EnvDefaultFunc("SENTRY_BASE_URL", "https://sentry.io/api/"),
 				},
+				"organization": {
+					Description: "The default organization slug used by resources and data sources that do not set " +
+						"their own `organization`. The value can be sourced from the `SENTRY_ORGANIZATION` environment " +
+						"variable.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("SENTRY_ORGANIZATION", nil),
+				},
+				"platforms_url": {
+					Description: "The URL of a list of platforms accepted by the Sentry server, in addition to the " +
+						"catalogue shipped with the provider. It is fetched with the provider's credentials, and " +
+						"relative URLs are resolved against `base_url`. The response must be a JSON array of " +
+						"platform IDs, or of objects with an `id` field. By default, platforms are checked " +
+						"offline against the shipped catalogue only, and unknown platforms produce a warning.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"max_retries": {
+					Description: "The maximum number of times a request is retried after a rate limit, a server " +
+						"error or a connection error. The default value is `4`.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      4,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"min_retry_wait": {
+					Description: "The minimum time to wait between retries, as a duration such as `500ms` or `1s`. " +
+						"The default value is `1s`.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					Default:          "1s",
+					ValidateDiagFunc: validateDuration,
+				},
+				"max_retry_wait": {
+					Description: "The maximum time to wait between retries, as a duration such as `30s` or `1m`. " +
+						"Rate limited requests wait until the limit resets regardless. The default value is `30s`.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					Default:          "30s",
+					ValidateDiagFunc: validateDuration,
+				},
+				"max_concurrent_requests": {
+					Description: "The maximum number of concurrent requests made to Sentry. The provider follows " +
+						"the concurrent rate limit reported by Sentry on every response, and otherwise allows " +
+						"`10` concurrent requests.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"request_timeout": {
+					Description: "The time to wait for Sentry to respond to each attempt of a request, as a duration " +
+						"such as `30s` or `1m`. By default, requests do not time out.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validateDuration,
+				},
+				"read_only": {
+					Description: "Reject every request that would modify Sentry, so that a token with write access can " +
+						"safely be used to plan changes. Applying changes then fails without reaching Sentry. The " +
+						"value can be sourced from the `SENTRY_READ_ONLY` environment variable.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("SENTRY_READ_ONLY", false),
+				},
+				"writable_resources": {
+					Description: "The resource types, such as `sentry_dashboard`, that may modify Sentry. When set, " +
+						"changes to any other resource fail without reaching Sentry. `read_only` takes precedence. " +
+						"The value can be sourced from the `SENTRY_WRITABLE_RESOURCES` environment variable, as a " +
+						"comma-separated list.",
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"log_http_bodies": {
+					Description: "Include request and response bodies in the HTTP logs, with secrets such as key " +
+						"secrets, DSNs and plugin options redacted. Every request is logged at `TRACE` level, see " +
+						"`TF_LOG_PROVIDER`. The value can be sourced from the `SENTRY_LOG_HTTP_BODIES` environment " +
+						"variable.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("SENTRY_LOG_HTTP_BODIES", false),
+				},
+				"log_http_file": {
+					Description: "The path of a file every request is also logged to, as a JSON line with secrets " +
+						"redacted, for instance to attach to a support ticket. The value can be sourced from the " +
+						"`SENTRY_LOG_HTTP_FILE` environment variable.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("SENTRY_LOG_HTTP_FILE", nil),
+				},
 			},
 
 			ResourcesMap: map[string]*schema.Resource{
@@ -47,6 +146,14 @@ func NewProvider(version string) func() *schema.Provider {
 				"sentry_organization":                   resourceSentryOrganization(),
 				"sentry_plugin":                         resourceSentryPlugin(),
 				"sentry_project":                        resourceSentryProject(),
+				"sentry_project_codeowners":             resourceSentryProjectCodeowners(),
+				"sentry_project_data_scrubbing":         resourceSentryProjectDataScrubbing(),
+				"sentry_project_grouping":               resourceSentryProjectGrouping(),
+				"sentry_project_inbound_filters":        resourceSentryProjectInboundFilters(),
+				"sentry_project_ownership":              resourceSentryProjectOwnership(),
+				"sentry_project_service_hook":           resourceSentryProjectServiceHook(),
+				"sentry_project_security":               resourceSentryProjectSecurity(),
+				"sentry_project_team":                   resourceSentryProjectTeam(),
 				"sentry_rule":                           resourceSentryRule(),
 				"sentry_team":                           resourceSentryTeam(),
 			},
@@ -55,6 +162,7 @@ func NewProvider(version string) func() *schema.Provider {
 				"sentry_dashboard":                dataSourceSentryDashboard(),
 				"sentry_issue_alert":              dataSourceSentryIssueAlertSentryIssueAlert(),
 				"sentry_key":                      dataSourceSentryKey(),
+				"sentry_keys":                     dataSourceSentryKeys(),
 				"sentry_metric_alert":             dataSourceSentryMetricAlert(),
 				"sentry_organization":             dataSourceSentryOrganization(),
 				"sentry_organization_integration": dataSourceSentryOrganizationIntegration(),
@@ -62,6 +170,11 @@ func NewProvider(version string) func() *schema.Provider {
 			},
 		}
 
+		for name, r := range p.ResourcesMap {
+			withTimeouts(name, r)
+			withResourceType(name, r)
+		}
+
 		p.ConfigureContextFunc = configure(version, p)
 
 		return p
@@ -71,10 +184,92 @@ func NewProvider(version string) func() *schema.Provider {
 func configure(version string, p *schema.Provider) func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		config := Config{
-			UserAgent: p.UserAgent("terraform-provider-sentry", version),
-			Token:     d.Get("token").(string),
-			BaseURL:   d.Get("base_url").(string),
+			UserAgent:             p.UserAgent("terraform-provider-sentry", version),
+			Token:                 d.Get("token").(string),
+			BaseURL:               d.Get("base_url").(string),
+			Organization:          d.Get("organization").(string),
+			PlatformsURL:          d.Get("platforms_url").(string),
+			MaxRetries:            d.Get("max_retries").(int),
+			MaxConcurrentRequests: d.Get("max_concurrent_requests").(int),
+			ReadOnly:              d.Get("read_only").(bool),
+			LogHTTPBodies:         d.Get("log_http_bodies").(bool),
+			LogHTTPFile:           d.Get("log_http_file").(string),
+		}
+
+		var err error
+		if config.MinRetryWait, err = parseDuration(d.Get("min_retry_wait").(string)); err != nil {
+			return nil, diag.FromErr(err)
+		}
+		if config.MaxRetryWait, err = parseDuration(d.Get("max_retry_wait").(string)); err != nil {
+			return nil, diag.FromErr(err)
+		}
+		if config.RequestTimeout, err = parseDuration(d.Get("request_timeout").(string)); err != nil {
+			return nil, diag.FromErr(err)
+		}
+		if config.MinRetryWait > config.MaxRetryWait {
+			return nil, diag.Diagnostics{
+				{
+					Severity:      diag.Error,
+					Summary:       "Invalid retry wait bounds",
+					Detail:        fmt.Sprintf("min_retry_wait (%s) must not be greater than max_retry_wait (%s).", config.MinRetryWait, config.MaxRetryWait),
+					AttributePath: cty.GetAttrPath("min_retry_wait"),
+				},
+			}
+		}
+
+		// Operations without a timeout of their own leave time for all the
+		// retries of a request.
+		if budget := config.retryBudget(); budget > defaultResourceTimeout {
+			for _, r := range p.ResourcesMap {
+				setDefaultTimeouts(r, budget)
+			}
+		}
+
+		if v, ok := d.GetOk("writable_resources"); ok {
+			config.WritableResources = expandStringList(v.(*schema.Set).List())
+		} else if v := os.Getenv("SENTRY_WRITABLE_RESOURCES"); v != "" {
+			config.WritableResources = strings.Split(v, ",")
+			for i, name := range config.WritableResources {
+				config.WritableResources[i] = strings.TrimSpace(name)
+			}
+		}
+		for _, name := range config.WritableResources {
+			if _, ok := p.ResourcesMap[name]; !ok {
+				return nil, diag.Diagnostics{
+					{
+						Severity:      diag.Error,
+						Summary:       "Invalid writable resource type",
+						Detail:        fmt.Sprintf("%q in writable_resources is not a resource type of this provider.", name),
+						AttributePath: cty.GetAttrPath("writable_resources"),
+					},
+				}
+			}
+		}
+
+		return config.ProviderData(ctx)
+	}
+}
+
+// parseDuration parses a duration argument, treating an empty string as zero.
+func parseDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(s)
+}
+
+func validateDuration(i interface{}, path cty.Path) diag.Diagnostics {
+	v := i.(string)
+	if d, err := time.ParseDuration(v); err != nil || d < 0 {
+		msg := fmt.Sprintf("%s is not a valid duration, expected a value such as 30s or 1m", v)
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       msg,
+				Detail:        msg,
+				AttributePath: path,
+			},
 		}
-		return config.Client(ctx)
 	}
+	return nil
 }
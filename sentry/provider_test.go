@@ -1,10 +1,18 @@
 package sentry
 
 import (
+	"context"
+	"fmt"
+	"net/http"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/jianyuan/terraform-provider-sentry/internal/fakesentry"
 )
 
 var testOrganization = os.Getenv("SENTRY_TEST_ORGANIZATION")
@@ -39,3 +47,471 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("SENTRY_TEST_ORGANIZATION must be set for acceptance tests")
 	}
 }
+
+// testUnitOrganization is the organization seeded in every fake Sentry server.
+const testUnitOrganization = "test-org"
+
+// testUnitProvider returns a provider configured against a fresh in-memory
+// Sentry server, seeded with the testUnitOrganization organization. The
+// server is shut down when the test finishes.
+func testUnitProvider(t *testing.T) (*schema.Provider, *fakesentry.Server) {
+	t.Helper()
+	return testUnitProviderWithConfig(t, nil)
+}
+
+// testUnitProviderWithConfig is like testUnitProvider, with additional
+// provider arguments.
+func testUnitProviderWithConfig(t *testing.T, extra map[string]interface{}) (*schema.Provider, *fakesentry.Server) {
+	t.Helper()
+
+	srv := fakesentry.NewServer()
+	t.Cleanup(srv.Close)
+	srv.AddOrganization(testUnitOrganization, "Test Organization")
+
+	config := map[string]interface{}{
+		"token":    "test-token",
+		"base_url": srv.BaseURL(),
+	}
+	for k, v := range extra {
+		config[k] = v
+	}
+
+	p := NewProvider("test")()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(config))
+	if diags.HasError() {
+		t.Fatalf("failed to configure provider: %v", diags)
+	}
+	return p, srv
+}
+
+// testUnitResource drives a single resource through the same
+// plan/apply/refresh/import/destroy steps Terraform performs, against a
+// provider returned by testUnitProvider.
+type testUnitResource struct {
+	t        *testing.T
+	provider *schema.Provider
+	resource *schema.Resource
+	state    *terraform.InstanceState
+	base     map[string]interface{}
+}
+
+func newTestUnitResource(t *testing.T, p *schema.Provider, name string) *testUnitResource {
+	r, ok := p.ResourcesMap[name]
+	if !ok {
+		t.Fatalf("unknown resource: %s", name)
+	}
+	return &testUnitResource{t: t, provider: p, resource: r}
+}
+
+// withConfig sets the attributes merged into every configuration given to u,
+// so that tests only spell out what they change.
+func (u *testUnitResource) withConfig(base map[string]interface{}) *testUnitResource {
+	u.base = base
+	return u
+}
+
+// config merges config over the base configuration of u. Attributes set to nil
+// are left unset.
+func (u *testUnitResource) config(config map[string]interface{}) *terraform.ResourceConfig {
+	merged := make(map[string]interface{}, len(u.base)+len(config))
+	for k, v := range u.base {
+		merged[k] = v
+	}
+	for k, v := range config {
+		if v == nil {
+			delete(merged, k)
+		} else {
+			merged[k] = v
+		}
+	}
+	return terraform.NewResourceConfigRaw(merged)
+}
+
+// plan returns the diff between the current state and config.
+func (u *testUnitResource) plan(config map[string]interface{}) *terraform.InstanceDiff {
+	u.t.Helper()
+
+	c := u.config(config)
+	if diags := u.resource.Validate(c); diags.HasError() {
+		u.t.Fatalf("invalid config: %v", diags)
+	}
+	diff, err := u.resource.Diff(context.Background(), u.state, c, u.provider.Meta())
+	if err != nil {
+		u.t.Fatalf("plan failed: %s", err)
+	}
+	return diff
+}
+
+// expectPlanError fails the test unless planning config fails with an error
+// containing want.
+func (u *testUnitResource) expectPlanError(config map[string]interface{}, want string) {
+	u.t.Helper()
+
+	c := u.config(config)
+	var err error
+	if diags := u.resource.Validate(c); diags.HasError() {
+		err = fmt.Errorf("%v", diags)
+	} else {
+		_, err = u.resource.Diff(context.Background(), u.state, c, u.provider.Meta())
+	}
+	if err == nil || !strings.Contains(err.Error(), want) {
+		u.t.Errorf("expected plan error containing %q, got %v", want, err)
+	}
+}
+
+// apply plans and applies config, then refreshes the resulting state. The
+// warnings of the apply are returned.
+func (u *testUnitResource) apply(config map[string]interface{}) diag.Diagnostics {
+	u.t.Helper()
+
+	diff := u.plan(config)
+	if diff == nil || diff.Empty() {
+		return nil
+	}
+	state, diags := u.resource.Apply(context.Background(), u.state, diff, u.provider.Meta())
+	if diags.HasError() {
+		u.t.Fatalf("apply failed: %v", diags)
+	}
+	u.state = state
+	u.refresh()
+	return diags
+}
+
+// step applies config, checks the given attributes, and expects planning
+// config again to produce no changes. The warnings of the apply are returned.
+func (u *testUnitResource) step(config map[string]interface{}, want map[string]string) diag.Diagnostics {
+	u.t.Helper()
+
+	diags := u.apply(config)
+	u.checkAttrs(want)
+	u.expectNoChanges(config)
+	return diags
+}
+
+// expectApplyError plans and applies config, and returns the diagnostics of
+// the apply, failing the test if it succeeds.
+func (u *testUnitResource) expectApplyError(config map[string]interface{}) diag.Diagnostics {
+	u.t.Helper()
+
+	diff := u.plan(config)
+	_, diags := u.resource.Apply(context.Background(), u.state, diff, u.provider.Meta())
+	if !diags.HasError() {
+		u.t.Fatal("expected apply to fail")
+	}
+	return diags
+}
+
+// expectNoChanges fails the test if config would produce a non-empty plan.
+func (u *testUnitResource) expectNoChanges(config map[string]interface{}) {
+	u.t.Helper()
+
+	if diff := u.plan(config); diff != nil && !diff.Empty() {
+		u.t.Fatalf("expected an empty plan, got: %#v", diff.Attributes)
+	}
+}
+
+// refresh reads the resource and replaces the current state.
+func (u *testUnitResource) refresh() {
+	u.t.Helper()
+
+	if u.state == nil {
+		return
+	}
+	state, diags := u.resource.RefreshWithoutUpgrade(context.Background(), u.state, u.provider.Meta())
+	if diags.HasError() {
+		u.t.Fatalf("refresh failed: %v", diags)
+	}
+	u.state = state
+}
+
+// importState imports the resource by ID and returns the refreshed state,
+// leaving the current state untouched.
+func (u *testUnitResource) importState(id string) *terraform.InstanceState {
+	u.t.Helper()
+
+	ctx := context.Background()
+	data := u.resource.Data(&terraform.InstanceState{ID: id})
+	imported, err := u.resource.Importer.StateContext(ctx, data, u.provider.Meta())
+	if err != nil {
+		u.t.Fatalf("import failed: %s", err)
+	}
+	if len(imported) != 1 {
+		u.t.Fatalf("expected 1 imported resource, got %d", len(imported))
+	}
+	state, diags := u.resource.RefreshWithoutUpgrade(ctx, imported[0].State(), u.provider.Meta())
+	if diags.HasError() {
+		u.t.Fatalf("refresh after import failed: %v", diags)
+	}
+	if state == nil {
+		u.t.Fatalf("imported resource %s not found", id)
+	}
+	return state
+}
+
+// destroy deletes the resource and clears the current state. The previous
+// state is returned so the caller can check that the resource is gone.
+func (u *testUnitResource) destroy() *terraform.InstanceState {
+	u.t.Helper()
+
+	_, diags := u.resource.Apply(context.Background(), u.state, &terraform.InstanceDiff{Destroy: true}, u.provider.Meta())
+	if diags.HasError() {
+		u.t.Fatalf("destroy failed: %v", diags)
+	}
+	prev := u.state
+	u.state = nil
+	return prev
+}
+
+// expectGone fails the test if refreshing state still finds the resource.
+func (u *testUnitResource) expectGone(state *terraform.InstanceState) {
+	u.t.Helper()
+
+	refreshed, diags := u.resource.RefreshWithoutUpgrade(context.Background(), state, u.provider.Meta())
+	if diags.HasError() {
+		u.t.Fatalf("refresh failed: %v", diags)
+	}
+	if refreshed != nil && refreshed.ID != "" {
+		u.t.Fatalf("resource %s still exists", state.ID)
+	}
+}
+
+// checkAttrs fails the test if any of the given attributes do not match the
+// current state.
+func (u *testUnitResource) checkAttrs(want map[string]string) {
+	u.t.Helper()
+
+	if u.state == nil {
+		u.t.Fatal("resource has no state")
+	}
+	for k, v := range want {
+		if got := u.state.Attributes[k]; got != v {
+			u.t.Errorf("attribute %s: got %q, want %q", k, got, v)
+		}
+	}
+}
+
+// checkImport fails the test if importing id does not reproduce the current
+// state, ignoring attributes starting with any of the given prefixes.
+func (u *testUnitResource) checkImport(id string, ignorePrefixes ...string) {
+	u.t.Helper()
+
+	imported := u.importState(id)
+	if imported.ID != u.state.ID {
+		u.t.Errorf("imported ID: got %q, want %q", imported.ID, u.state.ID)
+	}
+attributes:
+	for k, v := range u.state.Attributes {
+		for _, prefix := range ignorePrefixes {
+			if strings.HasPrefix(k, prefix) {
+				continue attributes
+			}
+		}
+		if got := imported.Attributes[k]; got != v {
+			u.t.Errorf("imported attribute %s: got %q, want %q", k, got, v)
+		}
+	}
+}
+
+// testUnitReadDataSource reads the named data source with the given
+// configuration.
+func testUnitReadDataSource(t *testing.T, p *schema.Provider, name string, config map[string]interface{}) *schema.ResourceData {
+	t.Helper()
+
+	ds, ok := p.DataSourcesMap[name]
+	if !ok {
+		t.Fatalf("unknown data source %s", name)
+	}
+	d := schema.TestResourceDataRaw(t, ds.Schema, config)
+	if diags := ds.ReadContext(context.Background(), d, p.Meta()); diags.HasError() {
+		t.Fatalf("failed to read %s: %v", name, diags)
+	}
+	return d
+}
+
+func TestUnitProvider_defaultOrganization(t *testing.T) {
+	p, srv := testUnitProviderWithConfig(t, map[string]interface{}{
+		"organization": testUnitOrganization,
+	})
+	srv.AddTeam(testUnitOrganization, "team")
+	srv.AddProject(testUnitOrganization, "team", "project")
+
+	team := newTestUnitResource(t, p, "sentry_team")
+	team.step(map[string]interface{}{"name": "tf-team"}, map[string]string{
+		"id":           "tf-team",
+		"organization": testUnitOrganization,
+	})
+	team.checkImport("tf-team")
+
+	key := newTestUnitResource(t, p, "sentry_key")
+	key.apply(map[string]interface{}{
+		"project": "project",
+		"name":    "tf-key",
+	})
+	key.checkAttrs(map[string]string{
+		"organization": testUnitOrganization,
+	})
+	key.checkImport(buildTwoPartID("project", key.state.ID))
+
+	d := testUnitReadDataSource(t, p, "sentry_team", map[string]interface{}{
+		"slug": "tf-team",
+	})
+	if got := d.Get("organization").(string); got != testUnitOrganization {
+		t.Errorf("data source organization: got %q, want %q", got, testUnitOrganization)
+	}
+}
+
+func TestUnitProvider_missingOrganization(t *testing.T) {
+	p, _ := testUnitProvider(t)
+	r := p.ResourcesMap["sentry_team"]
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "tf-team",
+	})
+	_, err := r.Diff(context.Background(), nil, config, p.Meta())
+	if err == nil || !strings.Contains(err.Error(), "organization must be set") {
+		t.Errorf("expected a missing organization error, got %v", err)
+	}
+}
+
+func TestUnitProvider_retries(t *testing.T) {
+	p, srv := testUnitProviderWithConfig(t, map[string]interface{}{
+		"max_retries":    2,
+		"min_retry_wait": "1ms",
+		"max_retry_wait": "5ms",
+	})
+	srv.AddTeam(testUnitOrganization, "team")
+	client := p.Meta().(*ProviderData).Client
+	ctx := context.Background()
+
+	srv.FailNext(2, http.StatusServiceUnavailable)
+	before := srv.Requests()
+	if _, _, err := client.Teams.Get(ctx, testUnitOrganization, "team"); err != nil {
+		t.Fatalf("expected the request to succeed after retrying, got %v", err)
+	}
+	if got := srv.Requests() - before; got != 3 {
+		t.Errorf("got %d requests, want 3", got)
+	}
+
+	srv.FailNext(3, http.StatusServiceUnavailable)
+	before = srv.Requests()
+	if _, _, err := client.Teams.Get(ctx, testUnitOrganization, "team"); err == nil {
+		t.Fatal("expected the request to fail once retries are exhausted")
+	}
+	if got := srv.Requests() - before; got != 3 {
+		t.Errorf("got %d requests, want 3", got)
+	}
+}
+
+func TestUnitProvider_retriesWithoutResponse(t *testing.T) {
+	p, srv := testUnitProviderWithConfig(t, map[string]interface{}{
+		"max_retries":     2,
+		"min_retry_wait":  "1ms",
+		"max_retry_wait":  "5ms",
+		"request_timeout": "20ms",
+	})
+	srv.AddTeam(testUnitOrganization, "team")
+	client := p.Meta().(*ProviderData).Client
+
+	// Attempts that time out have no response to back off from.
+	srv.SetLatency(200 * time.Millisecond)
+	if _, _, err := client.Teams.Get(context.Background(), testUnitOrganization, "team"); err == nil {
+		t.Fatal("expected the request to fail once retries are exhausted")
+	}
+	srv.SetLatency(0)
+}
+
+func TestUnitProvider_invalidRetrySettings(t *testing.T) {
+	p := NewProvider("test")()
+
+	diags := p.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"min_retry_wait":  "soon",
+		"request_timeout": "-1s",
+	}))
+	if len(diags) != 2 {
+		t.Errorf("expected 2 validation errors, got %v", diags)
+	}
+
+	diags = p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"token":          "test-token",
+		"min_retry_wait": "1m",
+		"max_retry_wait": "1s",
+	}))
+	if !diags.HasError() {
+		t.Error("expected min_retry_wait greater than max_retry_wait to be rejected")
+	}
+}
+
+func TestUnitProvider_readOnly(t *testing.T) {
+	for name, extra := range map[string]map[string]interface{}{
+		"argument": {"read_only": true},
+		"env":      nil,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if extra == nil {
+				t.Setenv("SENTRY_READ_ONLY", "true")
+			}
+			p, srv := testUnitProviderWithConfig(t, extra)
+			srv.AddTeam(testUnitOrganization, "existing")
+
+			// Reads are allowed.
+			r := newTestUnitResource(t, p, "sentry_team")
+			state := r.importState(buildTwoPartID(testUnitOrganization, "existing"))
+			if state.Attributes["slug"] != "existing" {
+				t.Errorf("expected the existing team to be imported, got %v", state.Attributes)
+			}
+
+			// Changes never reach Sentry.
+			requests := srv.Requests()
+			diags := r.expectApplyError(map[string]interface{}{
+				"organization": testUnitOrganization,
+				"name":         "tf-team",
+			})
+			if len(diags) != 1 || diags[0].Summary != "The Sentry provider is read-only" {
+				t.Errorf("expected a read-only diagnostic, got %#v", diags)
+			}
+			if got := srv.Requests(); got != requests {
+				t.Errorf("expected no request to reach Sentry, got %d", got-requests)
+			}
+		})
+	}
+}
+
+func TestUnitProvider_writableResources(t *testing.T) {
+	p, _ := testUnitProviderWithConfig(t, map[string]interface{}{
+		"writable_resources": []interface{}{"sentry_team"},
+	})
+
+	team := newTestUnitResource(t, p, "sentry_team")
+	team.apply(map[string]interface{}{
+		"organization": testUnitOrganization,
+		"name":         "tf-team",
+		"slug":         "tf-team",
+	})
+
+	project := newTestUnitResource(t, p, "sentry_project")
+	diags := project.expectApplyError(map[string]interface{}{
+		"organization": testUnitOrganization,
+		"teams":        []interface{}{"tf-team"},
+		"name":         "tf-project",
+	})
+	if len(diags) != 1 || diags[0].Summary != "sentry_project may not modify Sentry" {
+		t.Fatalf("expected a writable resources diagnostic, got %#v", diags)
+	}
+	if !strings.Contains(diags[0].Detail, "sentry_team") {
+		t.Errorf("expected the detail to list writable resources, got %q", diags[0].Detail)
+	}
+
+	team.destroy()
+}
+
+func TestUnitProvider_invalidWritableResources(t *testing.T) {
+	t.Setenv("SENTRY_WRITABLE_RESOURCES", "sentry_team, sentry_nope")
+
+	p := NewProvider("test")()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"token": "test-token",
+	}))
+	if !diags.HasError() || !strings.Contains(diags[0].Detail, `"sentry_nope"`) {
+		t.Errorf("expected the unknown resource type to be rejected, got %v", diags)
+	}
+}
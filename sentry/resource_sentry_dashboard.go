@@ -2,7 +2,6 @@ package sentry
 
 import (
 	"context"
-	"net/http"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -22,14 +21,16 @@ func resourceSentryDashboard() *schema.Resource {
 		DeleteContext: resourceSentryDashboardDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importOrganizationScopedID,
 		},
+		CustomizeDiff: customizeDiffOrganization,
 
 		Schema: map[string]*schema.Schema{
 			"organization": {
-				Description: "The slug of the organization the dashboard belongs to.",
+				Description: "The slug of the organization the dashboard belongs to. Defaults to the provider `organization`.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 			},
 			"title": {
 				Description: "Dashboard title.",
@@ -191,6 +192,13 @@ func resourceSentryDashboard() *schema.Resource {
 	}
 }
 
+// dashboardAPIFields maps the dashboard payload fields to their attributes.
+var dashboardAPIFields = map[string]string{
+	"widgets": "widget",
+	"queries": "query",
+	"orderby": "order_by",
+}
+
 func resourceSentryDashboardObject(d *schema.ResourceData) *sentry.Dashboard {
 	dashboard := &sentry.Dashboard{
 		Title: sentry.String(d.Get("title").(string)),
@@ -254,7 +262,7 @@ func resourceSentryDashboardObject(d *schema.ResourceData) *sentry.Dashboard {
 }
 
 func resourceSentryDashboardCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	org := d.Get("organization").(string)
 	dashboardReq := resourceSentryDashboardObject(d)
@@ -265,7 +273,7 @@ func resourceSentryDashboardCreate(ctx context.Context, d *schema.ResourceData,
 	})
 	dashboard, _, err := client.Dashboards.Create(ctx, org, dashboardReq)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIErr(err, resourceSentryDashboard(), dashboardAPIFields)
 	}
 
 	d.SetId(buildTwoPartID(org, sentry.StringValue(dashboard.ID)))
@@ -273,11 +281,11 @@ func resourceSentryDashboardCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceSentryDashboardRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	org, dashboardID, err := splitSentryDashboardID(d.Id())
 	if err != nil {
-		diag.FromErr(err)
+		return diag.FromErr(err)
 	}
 
 	tflog.Debug(ctx, "Reading dashboard", map[string]interface{}{
@@ -285,18 +293,8 @@ func resourceSentryDashboardRead(ctx context.Context, d *schema.ResourceData, me
 		"dashboardID": dashboardID,
 	})
 	dashboard, _, err := client.Dashboards.Get(ctx, org, dashboardID)
-	if err != nil {
-		if sErr, ok := err.(*sentry.ErrorResponse); ok {
-			if sErr.Response.StatusCode == http.StatusNotFound {
-				tflog.Info(ctx, "Removing dashboard from state because it no longer exists in Sentry", map[string]interface{}{
-					"org":         org,
-					"dashboardID": dashboardID,
-				})
-				d.SetId("")
-				return nil
-			}
-		}
-		return diag.FromErr(err)
+	if found, diags := checkClientGet(ctx, err, d); !found {
+		return diags
 	}
 
 	d.SetId(buildTwoPartID(org, sentry.StringValue(dashboard.ID)))
@@ -310,7 +308,7 @@ func resourceSentryDashboardRead(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourceSentryDashboardUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	org, dashboardID, err := splitSentryDashboardID(d.Id())
 	if err != nil {
@@ -324,13 +322,13 @@ func resourceSentryDashboardUpdate(ctx context.Context, d *schema.ResourceData,
 	})
 	_, _, err = client.Dashboards.Update(ctx, org, dashboardID, dashboardReq)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIErr(err, resourceSentryDashboard(), dashboardAPIFields)
 	}
 	return resourceSentryDashboardRead(ctx, d, meta)
 }
 
 func resourceSentryDashboardDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	org, dashboardID, err := splitSentryDashboardID(d.Id())
 	if err != nil {
@@ -342,7 +340,7 @@ func resourceSentryDashboardDelete(ctx context.Context, d *schema.ResourceData,
 		"dashboardID": dashboardID,
 	})
 	_, err = client.Dashboards.Delete(ctx, org, dashboardID)
-	return diag.FromErr(err)
+	return checkClientDelete(ctx, err, d)
 }
 
 func splitSentryDashboardID(id string) (org string, dashboardID string, err error) {
@@ -78,7 +78,7 @@ func testAccCheckSentryDashboardExists(n string, dashboardID *string) resource.T
 		if err != nil {
 			return err
 		}
-		client := testAccProvider.Meta().(*sentry.Client)
+		client := testAccProvider.Meta().(*ProviderData).Client
 		ctx := context.Background()
 		gotDashboard, _, err := client.Dashboards.Get(ctx, org, id)
 		if err != nil {
@@ -118,3 +118,52 @@ resource "sentry_dashboard" "test" {
 }
 	`, dashboardTitle)
 }
+
+func TestUnitSentryDashboard_basic(t *testing.T) {
+	p, _ := testUnitProvider(t)
+	r := newTestUnitResource(t, p, "sentry_dashboard").withConfig(map[string]interface{}{
+		"organization": testUnitOrganization,
+		"widget": []interface{}{
+			map[string]interface{}{
+				"title":        "Custom Widget",
+				"display_type": "world_map",
+				"query": []interface{}{
+					map[string]interface{}{
+						"name":       "Metric",
+						"fields":     []interface{}{"count()"},
+						"aggregates": []interface{}{"count()"},
+						"conditions": "!event.type:transaction",
+					},
+				},
+				"layout": []interface{}{
+					map[string]interface{}{
+						"x":     0,
+						"y":     0,
+						"w":     2,
+						"h":     1,
+						"min_h": 1,
+					},
+				},
+			},
+		},
+	})
+
+	r.step(map[string]interface{}{"title": "tf-dashboard"}, map[string]string{
+		"organization":                testUnitOrganization,
+		"title":                       "tf-dashboard",
+		"widget.#":                    "1",
+		"widget.0.title":              "Custom Widget",
+		"widget.0.display_type":       "world_map",
+		"widget.0.query.#":            "1",
+		"widget.0.query.0.conditions": "!event.type:transaction",
+		"widget.0.layout.0.w":         "2",
+	})
+
+	r.apply(map[string]interface{}{"title": "tf-dashboard-renamed"})
+	r.checkAttrs(map[string]string{
+		"title": "tf-dashboard-renamed",
+	})
+	r.checkImport(r.state.ID)
+
+	r.expectGone(r.destroy())
+}
@@ -2,11 +2,11 @@ package sentry
 
 import (
 	"context"
-	"net/http"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/jianyuan/go-sentry/v2/sentry"
@@ -19,7 +19,10 @@ func resourceSentryIssueAlert() *schema.Resource {
 			"values of conditions, filters, and actions. You can either inspect the request " +
 			"payload sent when creating or editing an issue alert on Sentry or inspect " +
 			"[Sentry's rules registry in the source code](https://github.com/getsentry/sentry/tree/master/src/sentry/rules). " +
-			"Since v0.11.2, you should also omit the name property of each condition, filter, and action.",
+			"Since v0.11.2, you should also omit the name property of each condition, filter, and action. " +
+			"The common conditions, filters and actions have typed `condition`, `filter` and `action` blocks, " +
+			"which are checked when planning; the `conditions`, `filters` and `actions` maps remain for the others " +
+			"and can be used alongside the blocks.",
 
 		CreateContext: resourceSentryIssueAlertCreate,
 		ReadContext:   resourceSentryIssueAlertRead,
@@ -27,8 +30,12 @@ func resourceSentryIssueAlert() *schema.Resource {
 		DeleteContext: resourceSentryIssueAlertDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importOrganizationProjectScopedID,
 		},
+		CustomizeDiff: customdiff.All(
+			customizeDiffOrganization,
+			customizeDiffIssueAlertRules,
+		),
 
 		Schema:        resourceSentryIssueAlertSchema(),
 		SchemaVersion: 1,
@@ -42,12 +49,18 @@ func resourceSentryIssueAlert() *schema.Resource {
 	}
 }
 
+// issueAlertAPIFields maps the rule payload fields to their attributes.
+var issueAlertAPIFields = map[string]string{
+	"projects": "project",
+}
+
 func resourceSentryIssueAlertSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"organization": {
-			Description: "The slug of the organization the issue alert belongs to.",
+			Description: "The slug of the organization the issue alert belongs to. Defaults to the provider `organization`.",
 			Type:        schema.TypeString,
-			Required:    true,
+			Optional:    true,
+			Computed:    true,
 		},
 		"project": {
 			Description: "The slug of the project to create the issue alert for.",
@@ -61,29 +74,49 @@ func resourceSentryIssueAlertSchema() map[string]*schema.Schema {
 			ValidateFunc: validation.StringLenBetween(1, 64),
 		},
 		"conditions": {
-			Description: "List of conditions.",
-			Type:        schema.TypeList,
-			Required:    true,
+			Description: "List of conditions, as maps of the rule payload. Use `condition` blocks instead " +
+				"for the common conditions. The conditions added outside of Terraform without a typed block " +
+				"are read here.",
+			Type:         schema.TypeList,
+			Optional:     true,
+			AtLeastOneOf: []string{"conditions", "condition"},
 			Elem: &schema.Schema{
 				Type: schema.TypeMap,
 			},
 		},
+		"condition": func() *schema.Schema {
+			s := issueAlertRuleBlockSchema("Conditions with a typed block, each setting exactly one of the "+
+				"blocks below. They are sent before the `conditions` maps.", issueAlertConditionClasses)
+			s.AtLeastOneOf = []string{"conditions", "condition"}
+			return s
+		}(),
 		"filters": {
-			Description: "List of filters.",
-			Type:        schema.TypeList,
-			Optional:    true,
+			Description: "List of filters, as maps of the rule payload. Use `filter` blocks instead for " +
+				"the common filters. The filters added outside of Terraform without a typed block are read here.",
+			Type:     schema.TypeList,
+			Optional: true,
 			Elem: &schema.Schema{
 				Type: schema.TypeMap,
 			},
 		},
+		"filter": issueAlertRuleBlockSchema("Filters with a typed block, each setting exactly one of the "+
+			"blocks below. They are sent before the `filters` maps.", issueAlertFilterClasses),
 		"actions": {
-			Description: "List of actions.",
-			Type:        schema.TypeList,
-			Required:    true,
+			Description: "List of actions, as maps of the rule payload. Use `action` blocks instead for " +
+				"the common actions. The actions added outside of Terraform without a typed block are read here.",
+			Type:         schema.TypeList,
+			Optional:     true,
+			AtLeastOneOf: []string{"actions", "action"},
 			Elem: &schema.Schema{
 				Type: schema.TypeMap,
 			},
 		},
+		"action": func() *schema.Schema {
+			s := issueAlertRuleBlockSchema("Actions with a typed block, each setting exactly one of the "+
+				"blocks below. They are sent before the `actions` maps.", issueAlertActionClasses)
+			s.AtLeastOneOf = []string{"actions", "action"}
+			return s
+		}(),
 		"action_match": {
 			Description:  "Trigger actions when an event is captured by Sentry and `any` or `all` of the specified conditions happen.",
 			Type:         schema.TypeString,
@@ -138,7 +171,7 @@ func resourceSentryIssueAlertStateUpgradeV0(ctx context.Context, rawState map[st
 	return rawState, nil
 }
 
-func resourceSentryIssueAlertObject(d *schema.ResourceData) *sentry.IssueAlert {
+func resourceSentryIssueAlertObject(d *schema.ResourceData) (*sentry.IssueAlert, error) {
 	alert := &sentry.IssueAlert{
 		Name:        sentry.String(d.Get("name").(string)),
 		ActionMatch: sentry.String(d.Get("action_match").(string)),
@@ -146,28 +179,23 @@ func resourceSentryIssueAlertObject(d *schema.ResourceData) *sentry.IssueAlert {
 		Frequency:   sentry.Int(d.Get("frequency").(int)),
 	}
 
-	conditionsIn := d.Get("conditions").([]interface{})
-	filtersIn := d.Get("filters").([]interface{})
-	actionsIn := d.Get("actions").([]interface{})
-
-	alert.Conditions = make([]*sentry.IssueAlertCondition, 0, len(conditionsIn))
-	for _, ic := range conditionsIn {
-		condition := new(sentry.IssueAlertCondition)
-		mapstructure.WeakDecode(ic, condition)
-		alert.Conditions = append(alert.Conditions, condition)
+	conditions, err := expandIssueAlertRules(d, "conditions", "condition", issueAlertConditionClasses)
+	if err != nil {
+		return nil, err
 	}
-	alert.Filters = make([]*sentry.IssueAlertFilter, 0, len(filtersIn))
-	for _, ia := range filtersIn {
-		filter := new(sentry.IssueAlertFilter)
-		mapstructure.WeakDecode(ia, filter)
-		alert.Filters = append(alert.Filters, filter)
+	alert.Conditions = issueAlertRulesOf[sentry.IssueAlertCondition](conditions)
+
+	filters, err := expandIssueAlertRules(d, "filters", "filter", issueAlertFilterClasses)
+	if err != nil {
+		return nil, err
 	}
-	alert.Actions = make([]*sentry.IssueAlertAction, 0, len(actionsIn))
-	for _, ia := range actionsIn {
-		action := new(sentry.IssueAlertAction)
-		mapstructure.WeakDecode(ia, action)
-		alert.Actions = append(alert.Actions, action)
+	alert.Filters = issueAlertRulesOf[sentry.IssueAlertFilter](filters)
+
+	actions, err := expandIssueAlertRules(d, "actions", "action", issueAlertActionClasses)
+	if err != nil {
+		return nil, err
 	}
+	alert.Actions = issueAlertRulesOf[sentry.IssueAlertAction](actions)
 
 	if v, ok := d.GetOk("environment"); ok {
 		alert.Environment = sentry.String(v.(string))
@@ -177,15 +205,60 @@ func resourceSentryIssueAlertObject(d *schema.ResourceData) *sentry.IssueAlert {
 		alert.Projects = []string{v.(string)}
 	}
 
-	return alert
+	return alert, nil
+}
+
+// expandIssueAlertRules returns the rule payload of the typed blocks of a
+// kind followed by the one of its maps.
+func expandIssueAlertRules(d *schema.ResourceData, mapsKey, blocksKey string, classes []*issueAlertRuleClass) ([]map[string]interface{}, error) {
+	rules, err := expandIssueAlertRuleBlocks(blocksKey, d.Get(blocksKey).([]interface{}), classes)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range d.Get(mapsKey).([]interface{}) {
+		rule := make(map[string]interface{})
+		mapstructure.WeakDecode(v, &rule)
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func issueAlertRulesOf[T ~map[string]interface{}](rules []map[string]interface{}) []*T {
+	out := make([]*T, 0, len(rules))
+	for _, rule := range rules {
+		v := T(rule)
+		out = append(out, &v)
+	}
+	return out
+}
+
+// customizeDiffIssueAlertRules checks that each condition, filter and action
+// block sets exactly one class.
+func customizeDiffIssueAlertRules(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	for kind, classes := range map[string][]*issueAlertRuleClass{
+		"condition": issueAlertConditionClasses,
+		"filter":    issueAlertFilterClasses,
+		"action":    issueAlertActionClasses,
+	} {
+		if !d.NewValueKnown(kind) {
+			continue
+		}
+		if err := validateIssueAlertRuleBlocks(kind, d.Get(kind).([]interface{}), classes); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func resourceSentryIssueAlertCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	org := d.Get("organization").(string)
 	project := d.Get("project").(string)
-	alertReq := resourceSentryIssueAlertObject(d)
+	alertReq, err := resourceSentryIssueAlertObject(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	tflog.Debug(ctx, "Creating issue alert", map[string]interface{}{
 		"org":       org,
@@ -194,7 +267,7 @@ func resourceSentryIssueAlertCreate(ctx context.Context, d *schema.ResourceData,
 	})
 	alert, _, err := client.IssueAlerts.Create(ctx, org, project, alertReq)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIErr(err, resourceSentryIssueAlert(), issueAlertAPIFields)
 	}
 
 	d.SetId(buildThreePartID(org, project, sentry.StringValue(alert.ID)))
@@ -202,38 +275,31 @@ func resourceSentryIssueAlertCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceSentryIssueAlertRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	org, project, alertID, err := splitSentryAlertID(d.Id())
 	if err != nil {
-		diag.FromErr(err)
+		return diag.FromErr(err)
 	}
 
 	tflog.Debug(ctx, "Reading issue alert", map[string]interface{}{"org": org, "project": project, "alertID": alertID})
 	alert, _, err := client.IssueAlerts.Get(ctx, org, project, alertID)
-	if err != nil {
-		if sErr, ok := err.(*sentry.ErrorResponse); ok {
-			if sErr.Response.StatusCode == http.StatusNotFound {
-				tflog.Info(ctx, "Removing issue alert from state because it no longer exists in Sentry", map[string]interface{}{"org": org, "project": project, "alertID": alertID})
-				d.SetId("")
-				return nil
-			}
-		}
-		return diag.FromErr(err)
+	if found, diags := checkClientGet(ctx, err, d); !found {
+		return diags
 	}
 
-	conditions := followShape(d.Get("conditions"), normalizeSentryIssueAlertProperty(alert.Conditions))
-	filters := followShape(d.Get("filters"), normalizeSentryIssueAlertProperty(alert.Filters))
-	actions := followShape(d.Get("actions"), normalizeSentryIssueAlertProperty(alert.Actions))
+	retErr := multierror.Append(
+		setIssueAlertRules(ctx, d, "conditions", "condition", normalizeSentryIssueAlertProperty(alert.Conditions), issueAlertConditionClasses),
+		setIssueAlertRules(ctx, d, "filters", "filter", normalizeSentryIssueAlertProperty(alert.Filters), issueAlertFilterClasses),
+		setIssueAlertRules(ctx, d, "actions", "action", normalizeSentryIssueAlertProperty(alert.Actions), issueAlertActionClasses),
+	)
 
 	d.SetId(buildThreePartID(org, project, sentry.StringValue(alert.ID)))
-	retErr := multierror.Append(
+	retErr = multierror.Append(
+		retErr,
 		d.Set("organization", org),
 		d.Set("projects", alert.Projects),
 		d.Set("name", alert.Name),
-		d.Set("conditions", conditions),
-		d.Set("filters", filters),
-		d.Set("actions", actions),
 		d.Set("action_match", alert.ActionMatch),
 		d.Set("filter_match", alert.FilterMatch),
 		d.Set("frequency", alert.Frequency),
@@ -250,13 +316,16 @@ func resourceSentryIssueAlertRead(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceSentryIssueAlertUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	org, project, alertID, err := splitSentryAlertID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	alertReq := resourceSentryIssueAlertObject(d)
+	alertReq, err := resourceSentryIssueAlertObject(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	tflog.Debug(ctx, "Updating issue alert", map[string]interface{}{
 		"org":     org,
@@ -265,13 +334,13 @@ func resourceSentryIssueAlertUpdate(ctx context.Context, d *schema.ResourceData,
 	})
 	_, _, err = client.IssueAlerts.Update(ctx, org, project, alertID, alertReq)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIErr(err, resourceSentryIssueAlert(), issueAlertAPIFields)
 	}
 	return resourceSentryIssueAlertRead(ctx, d, meta)
 }
 
 func resourceSentryIssueAlertDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	org, project, alertID, err := splitSentryAlertID(d.Id())
 	if err != nil {
@@ -284,7 +353,7 @@ func resourceSentryIssueAlertDelete(ctx context.Context, d *schema.ResourceData,
 		"alertID": alertID,
 	})
 	_, err = client.IssueAlerts.Delete(ctx, org, project, alertID)
-	return diag.FromErr(err)
+	return checkClientDelete(ctx, err, d)
 }
 
 func normalizeSentryIssueAlertProperty[T interface{ ~map[string]interface{} }](v []*T) []interface{} {
@@ -298,3 +367,54 @@ func normalizeSentryIssueAlertProperty[T interface{ ~map[string]interface{} }](v
 	}
 	return out
 }
+
+// setIssueAlertRules sets the rules of a kind as maps, following the shape of
+// the configuration, unless typed blocks are used. The rules with a typed
+// block are then set as blocks, except for the ones beyond the blocks of
+// their class when the maps are used for it too; the others, such as the ones
+// added outside of Terraform, remain in the maps so that they show as a diff.
+func setIssueAlertRules(ctx context.Context, d *schema.ResourceData, mapsKey, blocksKey string, rules []interface{}, classes []*issueAlertRuleClass) error {
+	blocks := d.Get(blocksKey).([]interface{})
+	if len(blocks) == 0 {
+		return multierror.Append(
+			d.Set(mapsKey, followShape(d.Get(mapsKey), rules)),
+			d.Set(blocksKey, nil),
+		).ErrorOrNil()
+	}
+
+	remaining := make(map[string]int)
+	for _, v := range blocks {
+		block, _ := v.(map[string]interface{})
+		if class, _, err := issueAlertRuleBlockClass(block, classes); err == nil {
+			remaining[class.id]++
+		}
+	}
+	mapped := make(map[string]bool)
+	for _, v := range d.Get(mapsKey).([]interface{}) {
+		if id, ok := v.(map[string]interface{})["id"].(string); ok {
+			mapped[id] = true
+		}
+	}
+	var typed []map[string]interface{}
+	others := make([]interface{}, 0)
+	for _, v := range rules {
+		rule := v.(map[string]interface{})
+		class := issueAlertRuleClassByID(rule, classes)
+		if class != nil && (remaining[class.id] > 0 || !mapped[class.id]) {
+			remaining[class.id]--
+			typed = append(typed, rule)
+		} else {
+			others = append(others, rule)
+		}
+	}
+	if len(others) > 0 {
+		tflog.Debug(ctx, "Reading issue alert rules without a typed block as maps", map[string]interface{}{
+			"attribute": mapsKey,
+			"count":     len(others),
+		})
+	}
+	return multierror.Append(
+		d.Set(mapsKey, followShape(d.Get(mapsKey), others)),
+		d.Set(blocksKey, flattenIssueAlertRuleBlocks(typed, classes)),
+	).ErrorOrNil()
+}
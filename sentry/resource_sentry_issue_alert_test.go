@@ -150,7 +150,7 @@ func TestAccSentryIssueAlert_basic(t *testing.T) {
 }
 
 func testAccCheckSentryIssueAlertDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*sentry.Client)
+	client := testAccProvider.Meta().(*ProviderData).Client
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "sentry_issue_alert" {
@@ -193,7 +193,7 @@ func testAccCheckSentryIssueAlertExists(n string, alertID *string) resource.Test
 		if err != nil {
 			return err
 		}
-		client := testAccProvider.Meta().(*sentry.Client)
+		client := testAccProvider.Meta().(*ProviderData).Client
 		ctx := context.Background()
 		gotAlert, _, err := client.IssueAlerts.Get(ctx, org, project, id)
 		if err != nil {
@@ -298,3 +298,255 @@ resource "sentry_issue_alert" "test" {
 }
 	`, alertName)
 }
+
+func TestUnitSentryIssueAlert_basic(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+	srv.AddProject(testUnitOrganization, "team", "project")
+	r := newTestUnitResource(t, p, "sentry_issue_alert").withConfig(map[string]interface{}{
+		"organization": testUnitOrganization,
+		"project":      "project",
+		"action_match": "any",
+		"filter_match": "any",
+		"conditions": []interface{}{
+			map[string]interface{}{
+				"id": "sentry.rules.conditions.first_seen_event.FirstSeenEventCondition",
+			},
+			map[string]interface{}{
+				"id":             "sentry.rules.conditions.event_frequency.EventFrequencyCondition",
+				"value":          "100",
+				"comparisonType": "count",
+				"interval":       "1h",
+			},
+		},
+		"filters": []interface{}{
+			map[string]interface{}{
+				"id":    "sentry.rules.filters.level.LevelFilter",
+				"match": "eq",
+				"level": "50",
+			},
+		},
+		"actions": []interface{}{
+			map[string]interface{}{
+				"id":               "sentry.mail.actions.NotifyEmailAction",
+				"targetType":       "IssueOwners",
+				"targetIdentifier": "",
+			},
+		},
+	})
+
+	r.step(map[string]interface{}{"name": "tf-issue-alert", "frequency": 30}, map[string]string{
+		"organization":          testUnitOrganization,
+		"project":               "project",
+		"projects.#":            "1",
+		"projects.0":            "project",
+		"name":                  "tf-issue-alert",
+		"frequency":             "30",
+		"conditions.#":          "2",
+		"conditions.1.interval": "1h",
+		"conditions.1.value":    "100",
+		"filters.0.level":       "50",
+		"actions.0.targetType":  "IssueOwners",
+	})
+	if _, ok := r.state.Attributes["conditions.0.name"]; ok {
+		t.Error("server-side condition names should not be tracked")
+	}
+
+	r.apply(map[string]interface{}{"name": "tf-issue-alert-renamed", "frequency": 60})
+	r.checkAttrs(map[string]string{
+		"name":      "tf-issue-alert-renamed",
+		"frequency": "60",
+	})
+	// Conditions, filters and actions follow the shape of the configuration,
+	// which is unknown on import.
+	r.checkImport(r.state.ID, "conditions.", "filters.", "actions.")
+
+	r.expectGone(r.destroy())
+}
+
+func TestUnitSentryIssueAlert_typedBlocks(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+	srv.AddProject(testUnitOrganization, "team", "project")
+
+	block := func(class string, attrs map[string]interface{}) interface{} {
+		return map[string]interface{}{class: []interface{}{attrs}}
+	}
+	r := newTestUnitResource(t, p, "sentry_issue_alert").withConfig(map[string]interface{}{
+		"organization": testUnitOrganization,
+		"project":      "project",
+		"name":         "tf-issue-alert",
+		"action_match": "any",
+		"filter_match": "all",
+		"frequency":    30,
+		"condition": []interface{}{
+			block("first_seen_event", map[string]interface{}{}),
+			block("event_frequency", map[string]interface{}{
+				"value":    100,
+				"interval": "1h",
+			}),
+			block("event_frequency_percent", map[string]interface{}{
+				"comparison_type":     "percent",
+				"value":               12.5,
+				"interval":            "1h",
+				"comparison_interval": "1w",
+			}),
+		},
+		"action": []interface{}{
+			block("notify_email", map[string]interface{}{
+				"target_type":      "IssueOwners",
+				"fallthrough_type": "ActiveMembers",
+			}),
+			block("slack", map[string]interface{}{
+				"workspace": "123",
+				"channel":   "#alerts",
+				"tags":      []interface{}{"environment", "level"},
+			}),
+			block("sentry_app", map[string]interface{}{
+				"sentry_app_installation_uuid": "b6f2a1c4",
+				"settings": map[string]interface{}{
+					"team": "backend",
+				},
+			}),
+		},
+	})
+	filters := func(level string) map[string]interface{} {
+		return map[string]interface{}{
+			"filter": []interface{}{
+				block("level", map[string]interface{}{
+					"match": "gte",
+					"level": level,
+				}),
+				block("tagged_event", map[string]interface{}{
+					"key":   "environment",
+					"match": "is",
+				}),
+			},
+		}
+	}
+
+	r.step(filters("error"), map[string]string{
+		"conditions.#":                   "0",
+		"condition.#":                    "3",
+		"condition.0.first_seen_event.#": "1",
+		"condition.1.event_frequency.0.comparison_type":      "count",
+		"condition.1.event_frequency.0.value":                "100",
+		"condition.2.event_frequency_percent.0.value":        "12.5",
+		"filter.0.level.0.level":                             "error",
+		"filter.1.tagged_event.0.value":                      "",
+		"action.0.notify_email.0.target_identifier":          "",
+		"action.1.slack.0.tags.#":                            "2",
+		"action.1.slack.0.tags.1":                            "level",
+		"action.2.sentry_app.0.settings.team":                "backend",
+		"action.2.sentry_app.0.sentry_app_installation_uuid": "b6f2a1c4",
+	})
+
+	// The blocks are sent as the rule payload of Sentry.
+	alert, _, err := p.Meta().(*ProviderData).Client.IssueAlerts.Get(context.Background(), testUnitOrganization, "project", r.state.Attributes["internal_id"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := (*alert.Conditions[1])["comparisonType"]; got != "count" {
+		t.Errorf("unexpected comparisonType: %v", got)
+	}
+	if got := (*alert.Filters[0])["level"]; got != "40" {
+		t.Errorf("unexpected level: %v", got)
+	}
+	if got := (*alert.Actions[1])["tags"]; got != "environment,level" {
+		t.Errorf("unexpected tags: %v", got)
+	}
+
+	r.apply(filters("fatal"))
+	r.checkAttrs(map[string]string{"filter.0.level.0.level": "fatal"})
+
+	invalid := filters("fatal")
+	invalid["condition"] = []interface{}{
+		map[string]interface{}{
+			"first_seen_event": []interface{}{map[string]interface{}{}},
+			"regression_event": []interface{}{map[string]interface{}{}},
+		},
+	}
+	r.expectPlanError(invalid, "condition.0: only one of")
+	invalid["condition"] = []interface{}{map[string]interface{}{}}
+	r.expectPlanError(invalid, "condition.0: one of")
+	invalid["condition"] = []interface{}{block("event_frequency", map[string]interface{}{
+		"value":    100,
+		"interval": "2h",
+	})}
+	r.expectPlanError(invalid, "event_frequency.0.interval to be one of")
+
+	invalid = filters("fatal")
+	invalid["action"] = []interface{}{block("notify_email", map[string]interface{}{
+		"target_type": "Team",
+	})}
+	diags := r.expectApplyError(invalid)
+	if len(diags) != 1 || diags[0].Summary != "action.0.notify_email: target_identifier is required when target_type is Team" {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+
+	// The maps remain available for the other classes.
+	maps := filters("fatal")
+	maps["action"] = nil
+	maps["actions"] = []interface{}{map[string]interface{}{
+		"id":      "sentry.rules.actions.notify_event_service.NotifyEventServiceAction",
+		"service": "webhooks",
+	}}
+	r.step(maps, map[string]string{
+		"action.#":          "0",
+		"actions.#":         "1",
+		"actions.0.service": "webhooks",
+		"condition.#":       "3",
+	})
+
+	// Rules added outside of Terraform show as a diff, in the maps if they
+	// have no typed block.
+	client := p.Meta().(*ProviderData).Client
+	alert, _, err = client.IssueAlerts.Get(context.Background(), testUnitOrganization, "project", r.state.Attributes["internal_id"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	alert.Conditions = append(alert.Conditions,
+		&sentry.IssueAlertCondition{"id": "sentry.rules.conditions.reappeared_event.ReappearedEventCondition"},
+		&sentry.IssueAlertCondition{"id": "sentry.rules.conditions.high_priority_issue.HighPriorityIssueCondition"},
+	)
+	alert.Filters = append(alert.Filters, &sentry.IssueAlertFilter{
+		"id":    "sentry.rules.filters.issue_category.IssueCategoryFilter",
+		"value": "1",
+	})
+	if _, _, err := client.IssueAlerts.Update(context.Background(), testUnitOrganization, "project", sentry.StringValue(alert.ID), alert); err != nil {
+		t.Fatal(err)
+	}
+	r.refresh()
+	r.checkAttrs(map[string]string{
+		"condition.#":                    "4",
+		"condition.3.reappeared_event.#": "1",
+		"conditions.#":                   "1",
+		"conditions.0.id":                "sentry.rules.conditions.high_priority_issue.HighPriorityIssueCondition",
+		"filter.#":                       "2",
+		"filters.#":                      "1",
+		"filters.0.value":                "1",
+		"actions.0.service":              "webhooks",
+	})
+	diff := r.plan(maps)
+	for attr, want := range map[string]string{"condition.#": "3", "conditions.#": "0", "filters.#": "0"} {
+		if d, ok := diff.Attributes[attr]; !ok || d.New != want {
+			t.Errorf("expected %s to become %s, got: %#v", attr, want, diff.Attributes)
+		}
+	}
+	r.apply(maps)
+	r.expectNoChanges(maps)
+
+	// The maps can hold rules of a class used by the blocks too.
+	maps["conditions"] = []interface{}{
+		map[string]interface{}{"id": "sentry.rules.conditions.first_seen_event.FirstSeenEventCondition"},
+		map[string]interface{}{"id": "sentry.rules.conditions.high_priority_issue.HighPriorityIssueCondition"},
+	}
+	r.step(maps, map[string]string{
+		"condition.#":                    "3",
+		"condition.0.first_seen_event.#": "1",
+		"conditions.#":                   "2",
+		"conditions.0.id":                "sentry.rules.conditions.first_seen_event.FirstSeenEventCondition",
+	})
+
+	r.expectGone(r.destroy())
+}
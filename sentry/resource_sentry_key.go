@@ -2,31 +2,43 @@ package sentry
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/jianyuan/go-sentry/v2/sentry"
 )
 
 func resourceSentryKey() *schema.Resource {
 	return &schema.Resource{
-		Description: "Sentry Key resource.",
+		Description: "Sentry Key resource. Changing `rotation_trigger` rotates the key without downtime: a new " +
+			"key replaces it, and the previous key keeps accepting events until `rotation_overlap` has elapsed. " +
+			"The next apply after that deactivates then deletes the previous key.",
 
 		CreateContext: resourceSentryKeyCreate,
 		ReadContext:   resourceSentryKeyRead,
 		UpdateContext: resourceSentryKeyUpdate,
 		DeleteContext: resourceSentryKeyDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: importOrganizationProjectAndID,
+			StateContext: importSentryKey,
 		},
+		CustomizeDiff: customdiff.All(
+			customizeDiffOrganization,
+			customizeDiffKeyRotation,
+		),
 
 		Schema: map[string]*schema.Schema{
 			"organization": {
-				Description: "The slug of the organization the key should be created for.",
+				Description: "The slug of the organization the key should be created for. Defaults to the provider `organization`.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 			},
 			"project": {
 				Description: "The slug of the project the key should be created for.",
@@ -54,9 +66,11 @@ func resourceSentryKey() *schema.Resource {
 				Computed:    true,
 			},
 			"is_active": {
-				Description: "Flag indicating the key is active.",
-				Type:        schema.TypeBool,
-				Computed:    true,
+				Description: "Flag indicating the key is active. Set to `false` to reject the events sent with the " +
+					"key, such as when its DSN leaked.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
 			},
 			"rate_limit_window": {
 				Description: "Length of time that will be considered when checking the rate limit.",
@@ -85,37 +99,109 @@ func resourceSentryKey() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"browser_sdk_version": {
+				Description: "The version of the browser SDK the JavaScript Loader Script of the key loads, such as " +
+					"`latest` or `7.x`.",
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"dynamic_sdk_loader_options": {
+				Description: "The features of the browser SDK the JavaScript Loader Script of the key enables.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"has_performance": {
+							Description: "Whether to enable performance monitoring.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+						},
+						"has_replay": {
+							Description: "Whether to enable session replay.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+						},
+						"has_debug": {
+							Description: "Whether to enable the debug logs of the SDK.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+						},
+					},
+				},
+			},
+			"loader_script_url": {
+				Description: "The URL of the JavaScript Loader Script of the key.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"rotation_trigger": {
+				Description: "An arbitrary value, such as a date, which rotates the key when changed. Setting or " +
+					"removing it does not rotate the key.",
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"rotation_overlap": {
+				Description:      "How long the previous key keeps accepting events after a rotation, such as `24h`.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "24h",
+				ValidateDiagFunc: validateDuration,
+			},
+			"rotated_at": {
+				Description: "When the key was last rotated, in RFC 3339 format.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"previous_key_id": {
+				Description: "The ID of the key replaced by the last rotation, until it is deleted.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"previous_dsn_public": {
+				Description: "The DSN of the key replaced by the last rotation, until it is deleted.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 		},
 	}
 }
 
 func resourceSentryKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	org := d.Get("organization").(string)
 	project := d.Get("project").(string)
 
-	_, resp, err := client.Projects.Get(ctx, org, project)
-	if found, err := checkClientGet(resp, err, d); !found {
-		return diag.Errorf("project not found \"%v\": %v", project, err)
+	_, _, err := client.Projects.Get(ctx, org, project)
+	if err != nil {
+		if isNotFoundError(err) {
+			return diag.Errorf("project not found \"%v\": %v", project, err)
+		}
+		return diagFromAPIErr(err, nil, nil)
 	}
 
-	params := &sentry.CreateProjectKeyParams{
-		Name: d.Get("name").(string),
-		RateLimit: &sentry.ProjectKeyRateLimit{
-			Window: d.Get("rate_limit_window").(int),
-			Count:  d.Get("rate_limit_count").(int),
-		},
+	var isActive *bool
+	if v, ok := d.GetOkExists("is_active"); ok {
+		isActive = sentry.Bool(v.(bool))
 	}
 
 	tflog.Debug(ctx, "Creating Sentry key", map[string]interface{}{
-		"keyName": params.Name,
+		"keyName": d.Get("name").(string),
 		"org":     org,
 		"project": project,
 	})
-	key, _, err := client.ProjectKeys.Create(ctx, org, project, params)
+	key, err := createProjectKey(ctx, client, d, org, project, isActive)
+	if key != nil {
+		d.SetId(key.ID)
+	}
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIErr(err, resourceSentryKey(), projectKeyAPIFields)
 	}
 	tflog.Debug(ctx, "Created Sentry key", map[string]interface{}{
 		"keyID":   key.ID,
@@ -123,13 +209,12 @@ func resourceSentryKeyCreate(ctx context.Context, d *schema.ResourceData, meta i
 		"org":     org,
 		"project": project,
 	})
-	d.SetId(key.ID)
 
 	return resourceSentryKeyRead(ctx, d, meta)
 }
 
 func resourceSentryKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	id := d.Id()
 	org := d.Get("organization").(string)
@@ -141,91 +226,118 @@ func resourceSentryKeyRead(ctx context.Context, d *schema.ResourceData, meta int
 		"project": project,
 	})
 
-	listParams := &sentry.ListCursorParams{}
-	var allKeys []*sentry.ProjectKey
-	for {
-		keys, resp, err := client.ProjectKeys.List(ctx, org, project, listParams)
-		if found, err := checkClientGet(resp, err, d); !found {
-			return diag.FromErr(err)
+	key, err := getProjectKey(ctx, client, org, project, id)
+	if found, diags := checkClientGet(ctx, err, d); !found {
+		return diags
+	}
+
+	retErr := multierror.Append(
+		d.Set("name", key.Name),
+		d.Set("public", key.Public),
+		d.Set("secret", key.Secret),
+		d.Set("project_id", key.ProjectID),
+		d.Set("is_active", key.IsActive),
+		d.Set("dsn_secret", key.DSN.Secret),
+		d.Set("dsn_public", key.DSN.Public),
+		d.Set("dsn_csp", key.DSN.CSP),
+		d.Set("browser_sdk_version", key.BrowserSdkVersion),
+		d.Set("dynamic_sdk_loader_options", flattenProjectKeyLoaderOptions(key.DynamicSdkLoaderOptions)),
+		d.Set("loader_script_url", key.DSN.CDN),
+	)
+	if previousID := d.Get("previous_key_id").(string); previousID != "" {
+		previous, err := getProjectKey(ctx, client, org, project, previousID)
+		if err != nil && !isNotFoundError(err) {
+			return diagFromAPIErr(err, nil, nil)
 		}
-		allKeys = append(allKeys, keys...)
-		if resp.Cursor == "" {
-			break
+		if previous != nil {
+			retErr = multierror.Append(retErr, d.Set("previous_dsn_public", previous.DSN.Public))
+		} else {
+			tflog.Warn(ctx, "Previous key no longer exists", map[string]interface{}{
+				"keyID": previousID,
+			})
+			retErr = multierror.Append(
+				retErr,
+				d.Set("previous_key_id", ""),
+				d.Set("previous_dsn_public", ""),
+			)
 		}
-		listParams.Cursor = resp.Cursor
 	}
-	tflog.Trace(ctx, "Read Sentry keys", map[string]interface{}{
-		"keyCount": len(allKeys),
-		"keys":     allKeys,
-	})
+	if key.RateLimit != nil {
+		retErr = multierror.Append(
+			retErr,
+			d.Set("rate_limit_window", key.RateLimit.Window),
+			d.Set("rate_limit_count", key.RateLimit.Count),
+		)
+	}
+	return diag.FromErr(retErr.ErrorOrNil())
+}
 
-	found := false
+func resourceSentryKeyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
-	for _, key := range allKeys {
-		if key.ID == id {
-			tflog.Debug(ctx, "Found Sentry key", map[string]interface{}{
-				"keyID":   id,
-				"org":     org,
-				"project": project,
-			})
+	id := d.Id()
+	org := d.Get("organization").(string)
+	project := d.Get("project").(string)
+
+	// The previous key is retired once its overlap has elapsed, which also
+	// happens before a new rotation.
+	if previousID, _ := d.GetChange("previous_key_id"); previousID.(string) != "" && d.Get("previous_key_id").(string) == "" {
+		tflog.Debug(ctx, "Retiring previous Sentry key", map[string]interface{}{
+			"keyID": previousID,
+		})
+		if err := retireProjectKey(ctx, client, org, project, previousID.(string)); err != nil {
+			return diagFromAPIErr(err, nil, nil)
+		}
+		retErr := multierror.Append(
+			d.Set("previous_key_id", ""),
+			d.Set("previous_dsn_public", ""),
+		)
+		if err := retErr.ErrorOrNil(); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if isKeyRotation(d) {
+		tflog.Debug(ctx, "Rotating Sentry key", map[string]interface{}{
+			"keyID": id,
+		})
+		key, err := createProjectKey(ctx, client, d, org, project, sentry.Bool(d.Get("is_active").(bool)))
+		if key != nil {
+			// The previous key is kept for the overlap, even if the new one
+			// could not be fully configured.
 			d.SetId(key.ID)
 			retErr := multierror.Append(
-				d.Set("name", key.Name),
-				d.Set("public", key.Public),
-				d.Set("secret", key.Secret),
-				d.Set("project_id", key.ProjectID),
-				d.Set("is_active", key.IsActive),
-				d.Set("dsn_secret", key.DSN.Secret),
-				d.Set("dsn_public", key.DSN.Public),
-				d.Set("dsn_csp", key.DSN.CSP),
+				d.Set("previous_key_id", id),
+				d.Set("rotated_at", time.Now().UTC().Format(time.RFC3339)),
 			)
-			if key.RateLimit != nil {
-				retErr = multierror.Append(
-					retErr,
-					d.Set("rate_limit_window", key.RateLimit.Window),
-					d.Set("rate_limit_count", key.RateLimit.Count),
-				)
-			}
 			if err := retErr.ErrorOrNil(); err != nil {
 				return diag.FromErr(err)
 			}
-
-			found = true
-
-			break
 		}
-	}
-
-	if !found {
-		tflog.Warn(ctx, "Sentry key could not be found...", map[string]interface{}{
-			"keyID": id,
+		if err != nil {
+			return diagFromAPIErr(err, resourceSentryKey(), projectKeyAPIFields)
+		}
+		tflog.Debug(ctx, "Rotated Sentry key", map[string]interface{}{
+			"keyID":         key.ID,
+			"previousKeyID": id,
 		})
-		d.SetId("")
+		return resourceSentryKeyRead(ctx, d, meta)
 	}
 
-	return nil
-}
-
-func resourceSentryKeyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
-
-	id := d.Id()
-	org := d.Get("organization").(string)
-	project := d.Get("project").(string)
-	params := &sentry.UpdateProjectKeyParams{
-		Name: d.Get("name").(string),
-		RateLimit: &sentry.ProjectKeyRateLimit{
-			Window: d.Get("rate_limit_window").(int),
-			Count:  d.Get("rate_limit_count").(int),
-		},
+	params := expandProjectKeyLoaderParams(d)
+	params.Name = d.Get("name").(string)
+	params.RateLimit = &sentry.ProjectKeyRateLimit{
+		Window: d.Get("rate_limit_window").(int),
+		Count:  d.Get("rate_limit_count").(int),
 	}
+	params.IsActive = sentry.Bool(d.Get("is_active").(bool))
 
 	tflog.Debug(ctx, "Updating Sentry key", map[string]interface{}{
 		"keyID": id,
 	})
-	key, _, err := client.ProjectKeys.Update(ctx, org, project, id, params)
+	key, err := updateProjectKey(ctx, client, org, project, id, params)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIErr(err, resourceSentryKey(), projectKeyAPIFields)
 	}
 	tflog.Debug(ctx, "Updated Sentry key", map[string]interface{}{
 		"keyID": id,
@@ -236,12 +348,21 @@ func resourceSentryKeyUpdate(ctx context.Context, d *schema.ResourceData, meta i
 }
 
 func resourceSentryKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	id := d.Id()
 	org := d.Get("organization").(string)
 	project := d.Get("project").(string)
 
+	if previousID := d.Get("previous_key_id").(string); previousID != "" {
+		tflog.Debug(ctx, "Deleting previous Sentry key", map[string]interface{}{
+			"keyID": previousID,
+		})
+		if _, err := client.ProjectKeys.Delete(ctx, org, project, previousID); err != nil && !isNotFoundError(err) {
+			return diagFromAPIErr(err, nil, nil)
+		}
+	}
+
 	tflog.Debug(ctx, "Deleting Sentry key", map[string]interface{}{
 		"keyID": id,
 	})
@@ -249,5 +370,228 @@ func resourceSentryKeyDelete(ctx context.Context, d *schema.ResourceData, meta i
 	tflog.Debug(ctx, "Deleted Sentry key", map[string]interface{}{
 		"keyID": id,
 	})
-	return diag.FromErr(err)
+	return checkClientDelete(ctx, err, d)
+}
+
+// importSentryKey imports a key, defaulting the rotation settings which
+// Sentry does not store.
+func importSentryKey(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resources, err := importOrganizationProjectAndID(ctx, d, meta)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Set("rotation_overlap", resourceSentryKey().Schema["rotation_overlap"].Default); err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+// projectKeyAPIFields maps the fields of project keys to their attributes.
+var projectKeyAPIFields = map[string]string{
+	"isActive":                "is_active",
+	"browserSdkVersion":       "browser_sdk_version",
+	"dynamicSdkLoaderOptions": "dynamic_sdk_loader_options",
+}
+
+// projectKey is a client key of a project, with the JavaScript Loader Script
+// settings go-sentry does not support.
+type projectKey struct {
+	sentry.ProjectKey
+	BrowserSdkVersion       string                  `json:"browserSdkVersion"`
+	DynamicSdkLoaderOptions projectKeyLoaderOptions `json:"dynamicSdkLoaderOptions"`
+}
+
+type projectKeyLoaderOptions struct {
+	HasPerformance bool `json:"hasPerformance"`
+	HasReplay      bool `json:"hasReplay"`
+	HasDebug       bool `json:"hasDebug"`
+}
+
+type projectKeyParams struct {
+	Name                    string                      `json:"name,omitempty"`
+	RateLimit               *sentry.ProjectKeyRateLimit `json:"rateLimit,omitempty"`
+	IsActive                *bool                       `json:"isActive,omitempty"`
+	BrowserSdkVersion       string                      `json:"browserSdkVersion,omitempty"`
+	DynamicSdkLoaderOptions *projectKeyLoaderOptions    `json:"dynamicSdkLoaderOptions,omitempty"`
+}
+
+// createProjectKey creates a key with the configured settings. The key is
+// returned along with the error when only its settings failed to apply.
+func createProjectKey(ctx context.Context, client *sentry.Client, d *schema.ResourceData, org, project string, isActive *bool) (*projectKey, error) {
+	created, _, err := client.ProjectKeys.Create(ctx, org, project, &sentry.CreateProjectKeyParams{
+		Name: d.Get("name").(string),
+		RateLimit: &sentry.ProjectKeyRateLimit{
+			Window: d.Get("rate_limit_window").(int),
+			Count:  d.Get("rate_limit_count").(int),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	key := &projectKey{ProjectKey: *created}
+
+	// The other settings can only be set once the key exists.
+	params := expandProjectKeyLoaderParams(d)
+	params.IsActive = isActive
+	if params.BrowserSdkVersion == "" && params.DynamicSdkLoaderOptions == nil && params.IsActive == nil {
+		return key, nil
+	}
+	updated, err := updateProjectKey(ctx, client, org, project, key.ID, params)
+	if err != nil {
+		return key, err
+	}
+	return updated, nil
+}
+
+func getProjectKey(ctx context.Context, client *sentry.Client, org, project, id string) (*projectKey, error) {
+	req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("0/projects/%v/%v/keys/%v/", org, project, id), nil)
+	if err != nil {
+		return nil, err
+	}
+	key := new(projectKey)
+	if _, err := client.Do(ctx, req, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// listProjectKeys returns all the client keys of a project.
+func listProjectKeys(ctx context.Context, client *sentry.Client, org, project string) ([]*projectKey, error) {
+	var allKeys []*projectKey
+	query := url.Values{}
+	for {
+		req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("0/projects/%v/%v/keys/?%s", org, project, query.Encode()), nil)
+		if err != nil {
+			return nil, err
+		}
+		var keys []*projectKey
+		resp, err := client.Do(ctx, req, &keys)
+		if err != nil {
+			return nil, err
+		}
+		allKeys = append(allKeys, keys...)
+		if resp.Cursor == "" {
+			return allKeys, nil
+		}
+		query.Set("cursor", resp.Cursor)
+	}
+}
+
+func updateProjectKey(ctx context.Context, client *sentry.Client, org, project, id string, params *projectKeyParams) (*projectKey, error) {
+	req, err := client.NewRequest(http.MethodPut, fmt.Sprintf("0/projects/%v/%v/keys/%v/", org, project, id), params)
+	if err != nil {
+		return nil, err
+	}
+	key := new(projectKey)
+	if _, err := client.Do(ctx, req, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// expandProjectKeyLoaderParams returns the configured JavaScript Loader
+// Script settings of a key.
+func expandProjectKeyLoaderParams(d *schema.ResourceData) *projectKeyParams {
+	params := &projectKeyParams{
+		BrowserSdkVersion: d.Get("browser_sdk_version").(string),
+	}
+	if v, ok := d.Get("dynamic_sdk_loader_options").([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		m := v[0].(map[string]interface{})
+		params.DynamicSdkLoaderOptions = &projectKeyLoaderOptions{
+			HasPerformance: m["has_performance"].(bool),
+			HasReplay:      m["has_replay"].(bool),
+			HasDebug:       m["has_debug"].(bool),
+		}
+	}
+	return params
+}
+
+func flattenProjectKeyLoaderOptions(options projectKeyLoaderOptions) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"has_performance": options.HasPerformance,
+			"has_replay":      options.HasReplay,
+			"has_debug":       options.HasDebug,
+		},
+	}
+}
+
+// retireProjectKey deactivates then deletes a key, so that it no longer
+// accepts events even if it cannot be deleted.
+func retireProjectKey(ctx context.Context, client *sentry.Client, org, project, id string) error {
+	if _, err := updateProjectKey(ctx, client, org, project, id, &projectKeyParams{IsActive: sentry.Bool(false)}); err != nil {
+		if isNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+	if _, err := client.ProjectKeys.Delete(ctx, org, project, id); err != nil && !isNotFoundError(err) {
+		return err
+	}
+	return nil
+}
+
+// isKeyRotation reports whether rotation_trigger changed from one value to
+// another.
+func isKeyRotation(d interface {
+	GetChange(string) (interface{}, interface{})
+}) bool {
+	o, n := d.GetChange("rotation_trigger")
+	return o.(string) != "" && n.(string) != "" && o != n
+}
+
+// keyRotationOverlapElapsed reports whether the previous key of the last
+// rotation no longer needs to accept events.
+func keyRotationOverlapElapsed(rotatedAt, overlap string) (bool, error) {
+	t, err := time.Parse(time.RFC3339, rotatedAt)
+	if err != nil {
+		return false, fmt.Errorf("invalid rotated_at: %w", err)
+	}
+	o, err := parseDuration(overlap)
+	if err != nil {
+		return false, fmt.Errorf("invalid rotation_overlap: %w", err)
+	}
+	return !time.Now().Before(t.Add(o)), nil
+}
+
+// customizeDiffKeyRotation plans the rotation of a key, and the retirement of
+// its previous key once the overlap has elapsed.
+func customizeDiffKeyRotation(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	retire := false
+	previousID := d.Get("previous_key_id").(string)
+	if previousID != "" {
+		elapsed, err := keyRotationOverlapElapsed(d.Get("rotated_at").(string), d.Get("rotation_overlap").(string))
+		if err != nil {
+			return err
+		}
+		retire = elapsed
+	}
+
+	if isKeyRotation(d) {
+		if previousID != "" && !retire {
+			return fmt.Errorf("cannot rotate the key before its previous key %s is deleted, once rotation_overlap has "+
+				"elapsed since %s", previousID, d.Get("rotated_at").(string))
+		}
+		for _, k := range []string{
+			"public", "secret", "dsn_secret", "dsn_public", "dsn_csp", "loader_script_url",
+			"rotated_at", "previous_key_id", "previous_dsn_public",
+		} {
+			if err := d.SetNewComputed(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if retire {
+		if err := d.SetNew("previous_key_id", ""); err != nil {
+			return err
+		}
+		return d.SetNew("previous_dsn_public", "")
+	}
+	return nil
 }
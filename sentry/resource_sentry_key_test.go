@@ -4,12 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	"github.com/jianyuan/go-sentry/v2/sentry"
 )
 
 func TestAccSentryKey_basic(t *testing.T) {
@@ -103,7 +103,7 @@ func TestAccSentryKey_RateLimit(t *testing.T) {
 }
 
 func testAccCheckSentryKeyDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*sentry.Client)
+	client := testAccProvider.Meta().(*ProviderData).Client
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "sentry_key" {
@@ -143,7 +143,7 @@ func testAccCheckSentryKeyExists(n string, keyID *string) resource.TestCheckFunc
 			return errors.New("no key ID is set")
 		}
 
-		client := testAccProvider.Meta().(*sentry.Client)
+		client := testAccProvider.Meta().(*ProviderData).Client
 		ctx := context.Background()
 		keys, _, err := client.ProjectKeys.List(
 			ctx,
@@ -199,3 +199,200 @@ resource "sentry_key" "test" {
 }
 	`, keyName, rateLimitWindow, rateLimitCount)
 }
+
+func TestUnitSentryKey_basic(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+	srv.AddProject(testUnitOrganization, "team", "project")
+	r := newTestUnitResource(t, p, "sentry_key").withConfig(map[string]interface{}{
+		"organization": testUnitOrganization,
+		"project":      "project",
+	})
+
+	r.step(map[string]interface{}{
+		"name":              "tf-key",
+		"rate_limit_window": 86400,
+		"rate_limit_count":  1000,
+	}, map[string]string{
+		"name":              "tf-key",
+		"is_active":         "true",
+		"rate_limit_window": "86400",
+		"rate_limit_count":  "1000",
+	})
+	for _, k := range []string{"public", "secret", "dsn_public", "dsn_secret", "dsn_csp"} {
+		if r.state.Attributes[k] == "" {
+			t.Errorf("attribute %s is not set", k)
+		}
+	}
+
+	id := r.state.ID
+	r.apply(map[string]interface{}{
+		"name":              "tf-key-renamed",
+		"rate_limit_window": 100,
+		"rate_limit_count":  100,
+	})
+	if r.state.ID != id {
+		t.Errorf("key should have been updated in place")
+	}
+	r.checkAttrs(map[string]string{
+		"name":              "tf-key-renamed",
+		"rate_limit_window": "100",
+		"rate_limit_count":  "100",
+	})
+	r.checkImport(buildThreePartID(testUnitOrganization, "project", id))
+
+	r.expectGone(r.destroy())
+}
+
+func TestUnitSentryKey_loaderScript(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+	srv.AddProject(testUnitOrganization, "team", "project")
+	base := map[string]interface{}{
+		"organization": testUnitOrganization,
+		"project":      "project",
+	}
+	r := newTestUnitResource(t, p, "sentry_key").withConfig(base)
+
+	// The loader settings of Sentry are kept when unset.
+	config := map[string]interface{}{"name": "tf-key"}
+	r.step(config, map[string]string{
+		"browser_sdk_version":                          "7.x",
+		"dynamic_sdk_loader_options.#":                 "1",
+		"dynamic_sdk_loader_options.0.has_performance": "false",
+		"dynamic_sdk_loader_options.0.has_replay":      "false",
+		"dynamic_sdk_loader_options.0.has_debug":       "false",
+	})
+	if !strings.HasSuffix(r.state.Attributes["loader_script_url"], r.state.Attributes["public"]+".min.js") {
+		t.Errorf("unexpected loader_script_url: %s", r.state.Attributes["loader_script_url"])
+	}
+
+	config["browser_sdk_version"] = "latest"
+	config["dynamic_sdk_loader_options"] = []interface{}{
+		map[string]interface{}{
+			"has_performance": true,
+			"has_replay":      true,
+		},
+	}
+	r.step(config, map[string]string{
+		"browser_sdk_version":                          "latest",
+		"dynamic_sdk_loader_options.0.has_performance": "true",
+		"dynamic_sdk_loader_options.0.has_replay":      "true",
+		"dynamic_sdk_loader_options.0.has_debug":       "false",
+	})
+	r.checkImport(buildThreePartID(testUnitOrganization, "project", r.state.ID))
+
+	// The loader settings are applied to new keys.
+	other := newTestUnitResource(t, p, "sentry_key").withConfig(base)
+	config["name"] = "tf-key-2"
+	config["browser_sdk_version"] = "8.x"
+	other.apply(config)
+	other.checkAttrs(map[string]string{
+		"browser_sdk_version":                     "8.x",
+		"dynamic_sdk_loader_options.0.has_replay": "true",
+	})
+
+	diags := other.expectApplyError(map[string]interface{}{
+		"name":                "tf-key-2",
+		"browser_sdk_version": "6.x",
+	})
+	if len(diags) != 1 || diags[0].Detail != `"6.x" is not a valid choice.` {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+}
+
+func TestUnitSentryKey_rotation(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+	srv.AddProject(testUnitOrganization, "team", "project")
+	r := newTestUnitResource(t, p, "sentry_key").withConfig(map[string]interface{}{
+		"organization": testUnitOrganization,
+		"project":      "project",
+		"name":         "tf-key",
+	})
+	client := p.Meta().(*ProviderData).Client
+
+	keyIDs := func() map[string]bool {
+		keys, _, err := client.ProjectKeys.List(context.Background(), testUnitOrganization, "project", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := make(map[string]bool)
+		for _, key := range keys {
+			ids[key.ID] = key.IsActive
+		}
+		return ids
+	}
+
+	config := map[string]interface{}{}
+	r.apply(config)
+	r.checkAttrs(map[string]string{"is_active": "true"})
+
+	// A leaked key can be deactivated.
+	config["is_active"] = false
+	r.apply(config)
+	r.checkAttrs(map[string]string{"is_active": "false"})
+	if active := keyIDs()[r.state.ID]; active {
+		t.Errorf("key should have been deactivated")
+	}
+	config["is_active"] = true
+	r.apply(config)
+	r.checkAttrs(map[string]string{"is_active": "true"})
+
+	// Setting the trigger for the first time does not rotate the key.
+	id := r.state.ID
+	config["rotation_trigger"] = "2024-01-01"
+	config["rotation_overlap"] = "1h"
+	r.apply(config)
+	if r.state.ID != id {
+		t.Errorf("key should not have been rotated")
+	}
+
+	dsn := r.state.Attributes["dsn_public"]
+	config["rotation_trigger"] = "2024-02-01"
+	r.apply(config)
+	if r.state.ID == id {
+		t.Fatalf("key should have been rotated")
+	}
+	r.checkAttrs(map[string]string{
+		"name":                "tf-key",
+		"is_active":           "true",
+		"previous_key_id":     id,
+		"previous_dsn_public": dsn,
+	})
+	if r.state.Attributes["dsn_public"] == dsn {
+		t.Errorf("rotated key should have a new DSN")
+	}
+	if ids := keyIDs(); len(ids) != 2 || !ids[id] || !ids[r.state.ID] {
+		t.Errorf("both keys should be active during the overlap, got %v", ids)
+	}
+	r.expectNoChanges(config)
+
+	config["rotation_trigger"] = "2024-03-01"
+	r.expectPlanError(config, "cannot rotate the key before its previous key")
+
+	// The previous key is deleted once the overlap has elapsed.
+	config["rotation_trigger"] = "2024-02-01"
+	config["rotation_overlap"] = "0s"
+	r.apply(config)
+	r.checkAttrs(map[string]string{
+		"previous_key_id":     "",
+		"previous_dsn_public": "",
+	})
+	if ids := keyIDs(); len(ids) != 1 || !ids[r.state.ID] {
+		t.Errorf("previous key should have been deleted, got %v", ids)
+	}
+	r.expectNoChanges(config)
+
+	// Both keys are deleted with the resource.
+	config["rotation_trigger"] = "2024-03-01"
+	config["rotation_overlap"] = "1h"
+	r.apply(config)
+	if r.state.Attributes["previous_key_id"] == "" {
+		t.Fatalf("key should have been rotated")
+	}
+	r.expectGone(r.destroy())
+	if ids := keyIDs(); len(ids) != 0 {
+		t.Errorf("keys should have been deleted, got %v", ids)
+	}
+}
@@ -3,7 +3,6 @@ package sentry
 import (
 	"context"
 	"fmt"
-	"net/http"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -22,14 +21,16 @@ func resourceSentryMetricAlert() *schema.Resource {
 		DeleteContext: resourceSentryMetricAlertDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importOrganizationProjectScopedID,
 		},
+		CustomizeDiff: customizeDiffOrganization,
 
 		Schema: map[string]*schema.Schema{
 			"organization": {
-				Description: "The slug of the organization the metric alert belongs to.",
+				Description: "The slug of the organization the metric alert belongs to. Defaults to the provider `organization`.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 			},
 			"project": {
 				Description: "The slug of the project to create the metric alert for.",
@@ -157,6 +158,14 @@ func resourceSentryMetricAlert() *schema.Resource {
 	}
 }
 
+// metricAlertAPIFields maps the alert rule payload fields to their
+// attributes.
+var metricAlertAPIFields = map[string]string{
+	"projects": "project",
+	"triggers": "trigger",
+	"actions":  "action",
+}
+
 func resourceSentryMetricAlertObject(d *schema.ResourceData) *sentry.MetricAlert {
 	alert := &sentry.MetricAlert{
 		Name:          sentry.String(d.Get("name").(string)),
@@ -198,7 +207,7 @@ func resourceSentryMetricAlertObject(d *schema.ResourceData) *sentry.MetricAlert
 }
 
 func resourceSentryMetricAlertCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	org := d.Get("organization").(string)
 	project := d.Get("project").(string)
@@ -212,7 +221,7 @@ func resourceSentryMetricAlertCreate(ctx context.Context, d *schema.ResourceData
 	})
 	alert, _, err := client.MetricAlerts.Create(ctx, org, project, alertReq)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIErr(err, resourceSentryMetricAlert(), metricAlertAPIFields)
 	}
 
 	d.SetId(buildThreePartID(org, project, sentry.StringValue(alert.ID)))
@@ -220,11 +229,11 @@ func resourceSentryMetricAlertCreate(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceSentryMetricAlertRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	org, project, alertID, err := splitSentryAlertID(d.Id())
 	if err != nil {
-		diag.FromErr(err)
+		return diag.FromErr(err)
 	}
 
 	tflog.Debug(ctx, "Reading metric alert", map[string]interface{}{
@@ -233,15 +242,8 @@ func resourceSentryMetricAlertRead(ctx context.Context, d *schema.ResourceData,
 		"alertID": alertID,
 	})
 	alert, _, err := client.MetricAlerts.Get(ctx, org, project, alertID)
-	if err != nil {
-		if sErr, ok := err.(*sentry.ErrorResponse); ok {
-			if sErr.Response.StatusCode == http.StatusNotFound {
-				tflog.Info(ctx, "Removing metric alert from state because it no longer exists in Sentry", map[string]interface{}{"org": org})
-				d.SetId("")
-				return nil
-			}
-		}
-		return diag.FromErr(err)
+	if found, diags := checkClientGet(ctx, err, d); !found {
+		return diags
 	}
 	tflog.Debug(ctx, "Read metric alert", map[string]interface{}{
 		"alert": fmt.Sprintf("%+v", alert),
@@ -273,7 +275,7 @@ func resourceSentryMetricAlertRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceSentryMetricAlertUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	org, project, alertID, err := splitSentryAlertID(d.Id())
 	if err != nil {
@@ -288,7 +290,7 @@ func resourceSentryMetricAlertUpdate(ctx context.Context, d *schema.ResourceData
 	})
 	alert, _, err := client.MetricAlerts.Update(ctx, org, project, alertID, alertReq)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIErr(err, resourceSentryMetricAlert(), metricAlertAPIFields)
 	}
 
 	d.SetId(buildThreePartID(org, project, sentry.StringValue(alert.ID)))
@@ -296,7 +298,7 @@ func resourceSentryMetricAlertUpdate(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceSentryMetricAlertDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	org, project, alertID, err := splitSentryAlertID(d.Id())
 	if err != nil {
@@ -309,7 +311,7 @@ func resourceSentryMetricAlertDelete(ctx context.Context, d *schema.ResourceData
 		"alertID": alertID,
 	})
 	_, err = client.MetricAlerts.Delete(ctx, org, project, alertID)
-	return diag.FromErr(err)
+	return checkClientDelete(ctx, err, d)
 }
 
 func expandMetricAlertTriggers(triggerList []interface{}) []*sentry.MetricAlertTrigger {
@@ -62,7 +62,7 @@ func TestAccSentryMetricAlert_basic(t *testing.T) {
 }
 
 func testAccCheckSentryMetricAlertDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*sentry.Client)
+	client := testAccProvider.Meta().(*ProviderData).Client
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "sentry_metric_alert" {
@@ -105,7 +105,7 @@ func testAccCheckSentryMetricAlertExists(n string, gotAlertID *string) resource.
 		if err != nil {
 			return err
 		}
-		client := testAccProvider.Meta().(*sentry.Client)
+		client := testAccProvider.Meta().(*ProviderData).Client
 		ctx := context.Background()
 		gotAlert, _, err := client.MetricAlerts.Get(ctx, org, project, alertID)
 		if err != nil {
@@ -153,3 +153,66 @@ resource "sentry_metric_alert" "test" {
 }
 	`, alertName)
 }
+
+func TestUnitSentryMetricAlert_basic(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+	srv.AddProject(testUnitOrganization, "team", "project")
+	r := newTestUnitResource(t, p, "sentry_metric_alert").withConfig(map[string]interface{}{
+		"organization":      testUnitOrganization,
+		"project":           "project",
+		"dataset":           "transactions",
+		"event_types":       []interface{}{"transaction"},
+		"query":             "http.url:http://testservice.com/stats",
+		"aggregate":         "p50(transaction.duration)",
+		"time_window":       50.0,
+		"threshold_type":    0,
+		"resolve_threshold": 100.0,
+	})
+
+	trigger := func(alertThreshold float64) []interface{} {
+		return []interface{}{
+			map[string]interface{}{
+				"action": []interface{}{
+					map[string]interface{}{
+						"type":              "email",
+						"target_type":       "team",
+						"target_identifier": "1",
+					},
+				},
+				"alert_threshold":   alertThreshold,
+				"label":             "critical",
+				"resolve_threshold": 100.0,
+				"threshold_type":    0,
+			},
+		}
+	}
+
+	r.step(map[string]interface{}{"name": "tf-metric-alert", "trigger": trigger(1000)}, map[string]string{
+		"organization":                         testUnitOrganization,
+		"project":                              "project",
+		"name":                                 "tf-metric-alert",
+		"dataset":                              "transactions",
+		"event_types.#":                        "1",
+		"event_types.0":                        "transaction",
+		"aggregate":                            "p50(transaction.duration)",
+		"time_window":                          "50",
+		"trigger.#":                            "1",
+		"trigger.0.alert_threshold":            "1000",
+		"trigger.0.action.#":                   "1",
+		"trigger.0.action.0.target_type":       "team",
+		"trigger.0.action.0.target_identifier": "1",
+	})
+	if r.state.Attributes["internal_id"] == "" {
+		t.Error("internal_id is not set")
+	}
+
+	r.apply(map[string]interface{}{"name": "tf-metric-alert-renamed", "trigger": trigger(500)})
+	r.checkAttrs(map[string]string{
+		"name":                      "tf-metric-alert-renamed",
+		"trigger.0.alert_threshold": "500",
+	})
+	r.checkImport(r.state.ID)
+
+	r.expectGone(r.destroy())
+}
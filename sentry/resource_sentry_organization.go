@@ -2,6 +2,7 @@ package sentry
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/hashicorp/go-multierror"
@@ -12,6 +13,34 @@ import (
 )
 
 func resourceSentryOrganization() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"name": {
+			Description: "The human readable name for the organization.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"slug": {
+			Description: "The unique URL slug for this organization.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+		},
+		"agree_terms": {
+			Description: "You agree to the applicable terms of service and privacy policy.",
+			Type:        schema.TypeBool,
+			Required:    true,
+		},
+		"internal_id": {
+			Description: "The internal ID for this organization.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+	// Data scrubbing settings of the organization apply to all its projects.
+	for k, v := range dataScrubbingSchema(false) {
+		s[k] = v
+	}
+
 	return &schema.Resource{
 		Description: "Sentry Organization resource.",
 
@@ -22,35 +51,14 @@ func resourceSentryOrganization() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customizeDiffDataScrubbingRules,
 
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Description: "The human readable name for the organization.",
-				Type:        schema.TypeString,
-				Required:    true,
-			},
-			"slug": {
-				Description: "The unique URL slug for this organization.",
-				Type:        schema.TypeString,
-				Optional:    true,
-				Computed:    true,
-			},
-			"agree_terms": {
-				Description: "You agree to the applicable terms of service and privacy policy.",
-				Type:        schema.TypeBool,
-				Required:    true,
-			},
-			"internal_id": {
-				Description: "The internal ID for this organization.",
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-		},
+		Schema: s,
 	}
 }
 
 func resourceSentryOrganizationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	params := &sentry.CreateOrganizationParams{
 		Name:       sentry.String(d.Get("name").(string)),
@@ -63,28 +71,29 @@ func resourceSentryOrganizationCreate(ctx context.Context, d *schema.ResourceDat
 	tflog.Debug(ctx, "Creating organization", map[string]interface{}{"org": params.Name})
 	organization, _, err := client.Organizations.Create(ctx, params)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIErr(err, resourceSentryOrganization(), nil)
 	}
 
 	d.SetId(sentry.StringValue(organization.Slug))
+
+	// Data scrubbing attributes left unset keep the defaults of Sentry.
+	if diags := updateOrganizationDataScrubbing(ctx, client, d, func(k string) bool {
+		_, ok := d.GetOkExists(k)
+		return ok
+	}); diags.HasError() {
+		return diags
+	}
 	return resourceSentryOrganizationRead(ctx, d, meta)
 }
 
 func resourceSentryOrganizationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 	org := d.Id()
 
 	tflog.Debug(ctx, "Reading organization", map[string]interface{}{"org": org})
 	organization, _, err := client.Organizations.Get(ctx, org)
-	if err != nil {
-		if sErr, ok := err.(*sentry.ErrorResponse); ok {
-			if sErr.Response.StatusCode == http.StatusNotFound {
-				tflog.Info(ctx, "Removing organization from state because it no longer exists in Sentry", map[string]interface{}{"org": org})
-				d.SetId("")
-				return nil
-			}
-		}
-		return diag.FromErr(err)
+	if found, diags := checkClientGet(ctx, err, d); !found {
+		return diags
 	}
 
 	retErr := multierror.Append(
@@ -92,12 +101,20 @@ func resourceSentryOrganizationRead(ctx context.Context, d *schema.ResourceData,
 		d.Set("slug", organization.Slug),
 		d.Set("agree_terms", true),
 		d.Set("internal_id", organization.ID),
+		flattenDataScrubbing(d, &dataScrubbing{
+			DataScrubber:         organization.DataScrubber,
+			DataScrubberDefaults: organization.DataScrubberDefaults,
+			ScrubIPAddresses:     organization.ScrubIPAddresses,
+			SensitiveFields:      &organization.SensitiveFields,
+			SafeFields:           &organization.SafeFields,
+			RelayPiiConfig:       organization.RelayPiiConfig,
+		}),
 	)
 	return diag.FromErr(retErr.ErrorOrNil())
 }
 
 func resourceSentryOrganizationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 	org := d.Id()
 	params := &sentry.UpdateOrganizationParams{
 		Name: sentry.String(d.Get("name").(string)),
@@ -109,18 +126,49 @@ func resourceSentryOrganizationUpdate(ctx context.Context, d *schema.ResourceDat
 	tflog.Debug(ctx, "Updating organization", map[string]interface{}{"org": org})
 	organization, _, err := client.Organizations.Update(ctx, org, params)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIErr(err, resourceSentryOrganization(), nil)
 	}
 
 	d.SetId(sentry.StringValue(organization.Slug))
+	if diags := updateOrganizationDataScrubbing(ctx, client, d, d.HasChange); diags.HasError() {
+		return diags
+	}
 	return resourceSentryOrganizationRead(ctx, d, meta)
 }
 
+// updateOrganizationDataScrubbing updates the data scrubbing attributes of
+// the organization for which include returns true.
+func updateOrganizationDataScrubbing(ctx context.Context, client *sentry.Client, d *schema.ResourceData, include func(k string) bool) diag.Diagnostics {
+	var keys []string
+	for _, k := range dataScrubbingKeys {
+		if include(k) {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	settings, err := expandDataScrubbing(d, include)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	tflog.Debug(ctx, "Updating organization data scrubbing", map[string]interface{}{
+		"org":        d.Id(),
+		"attributes": keys,
+	})
+	err = requestDataScrubbing(ctx, client, http.MethodPut, fmt.Sprintf("0/organizations/%v/", d.Id()), settings, nil)
+	if err != nil {
+		return diagFromAPIErr(err, resourceSentryOrganization(), dataScrubbingAPIFields)
+	}
+	return nil
+}
+
 func resourceSentryOrganizationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 	org := d.Id()
 
 	tflog.Debug(ctx, "Deleting organization", map[string]interface{}{"org": org})
 	_, err := client.Organizations.Delete(ctx, org)
-	return diag.FromErr(err)
+	return checkClientDelete(ctx, err, d)
 }
@@ -21,12 +21,14 @@ func resourceSentryOrganizationCodeMapping() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: importSentryOrganizationCodeMapping,
 		},
+		CustomizeDiff: customizeDiffOrganization,
 
 		Schema: map[string]*schema.Schema{
 			"organization": {
-				Description: "The slug of the organization the code mapping is under.",
+				Description: "The slug of the organization the code mapping is under. Defaults to the provider `organization`.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 			},
 			"integration_id": {
 				Description: "Sentry Organization Integration ID.",
@@ -70,7 +72,7 @@ func resourceSentryOrganizationCodeMapping() *schema.Resource {
 }
 
 func resourceSentryOrganizationCodeMappingCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	org := d.Get("organization").(string)
 
@@ -88,7 +90,7 @@ func resourceSentryOrganizationCodeMappingCreate(ctx context.Context, d *schema.
 	}
 	orgCodeMapping, _, err := client.OrganizationCodeMappings.Create(ctx, org, params)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIErr(err, resourceSentryOrganizationCodeMapping(), nil)
 	}
 
 	d.SetId(orgCodeMapping.ID)
@@ -97,7 +99,7 @@ func resourceSentryOrganizationCodeMappingCreate(ctx context.Context, d *schema.
 }
 
 func resourceSentryOrganizationCodeMappingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	id := d.Id()
 	org := d.Get("organization").(string)
@@ -121,8 +123,8 @@ func resourceSentryOrganizationCodeMappingRead(ctx context.Context, d *schema.Re
 	}
 	for {
 		keys, resp, err := client.OrganizationCodeMappings.List(ctx, org, params)
-		if err != nil {
-			return diag.FromErr(err)
+		if found, diags := checkClientGet(ctx, err, d); !found {
+			return diags
 		}
 		orgCodeMappings = append(orgCodeMappings, keys...)
 
@@ -150,11 +152,16 @@ func resourceSentryOrganizationCodeMappingRead(ctx context.Context, d *schema.Re
 		}
 	}
 
-	return diag.Errorf("Can't find Sentry Organization Code Mapping: %s", id)
+	tflog.Warn(ctx, "Sentry Organization Code Mapping not found, removing from state", map[string]interface{}{
+		"org": org,
+		"id":  id,
+	})
+	d.SetId("")
+	return nil
 }
 
 func resourceSentryOrganizationCodeMappingUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	id := d.Id()
 	org := d.Get("organization").(string)
@@ -173,7 +180,7 @@ func resourceSentryOrganizationCodeMappingUpdate(ctx context.Context, d *schema.
 	})
 	orgCodeMapping, _, err := client.OrganizationCodeMappings.Update(ctx, org, id, params)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIErr(err, resourceSentryOrganizationCodeMapping(), nil)
 	}
 
 	d.SetId(orgCodeMapping.ID)
@@ -183,7 +190,7 @@ func resourceSentryOrganizationCodeMappingUpdate(ctx context.Context, d *schema.
 }
 
 func resourceSentryOrganizationCodeMappingDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	id := d.Id()
 	org := d.Get("organization").(string)
@@ -193,11 +200,11 @@ func resourceSentryOrganizationCodeMappingDelete(ctx context.Context, d *schema.
 		"org": org,
 	})
 	_, err := client.OrganizationCodeMappings.Delete(ctx, org, id)
-	return diag.FromErr(err)
+	return checkClientDelete(ctx, err, d)
 }
 
 func importSentryOrganizationCodeMapping(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	org, id, err := splitTwoPartID(d.Id(), "organization-slug", "id")
+	org, id, err := splitTwoPartID(withDefaultOrganization(d.Id(), 2, meta), "organization-slug", "id")
 	if err != nil {
 		return nil, err
 	}
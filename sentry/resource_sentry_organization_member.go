@@ -19,14 +19,16 @@ func resourceSentryOrganizationMember() *schema.Resource {
 		DeleteContext: resourceSentryOrganizationMemberDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importOrganizationScopedID,
 		},
+		CustomizeDiff: customizeDiffOrganization,
 
 		Schema: map[string]*schema.Schema{
 			"organization": {
-				Description: "The slug of the organization the user should be invited to.",
+				Description: "The slug of the organization the user should be invited to. Defaults to the provider `organization`.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 			},
 			"email": {
 				Description: "The email of the organization member.",
@@ -66,7 +68,7 @@ func resourceSentryOrganizationMember() *schema.Resource {
 }
 
 func resourceSentryOrganizationMemberCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	org := d.Get("organization").(string)
 	params := &sentry.CreateOrganizationMemberParams{
@@ -88,7 +90,7 @@ func resourceSentryOrganizationMemberCreate(ctx context.Context, d *schema.Resou
 	})
 	member, _, err := client.OrganizationMembers.Create(ctx, org, params)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIErr(err, resourceSentryOrganizationMember(), nil)
 	}
 
 	d.SetId(buildTwoPartID(org, member.ID))
@@ -96,21 +98,20 @@ func resourceSentryOrganizationMemberCreate(ctx context.Context, d *schema.Resou
 }
 
 func resourceSentryOrganizationMemberRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	org, memberID, err := splitSentryOrganizationMemberID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	tflog.Debug(ctx, "Reading organization member", map[string]interface{}{
 		"org":      org,
 		"memberID": memberID,
 	})
-	member, resp, err := client.OrganizationMembers.Get(ctx, org, memberID)
-	if found, err := checkClientGet(resp, err, d); !found {
-		tflog.Info(ctx, "Removed organization membership from state because it no longer exists in Sentry", map[string]interface{}{
-			"org":      org,
-			"memberID": memberID,
-		})
-		return diag.FromErr(err)
+	member, _, err := client.OrganizationMembers.Get(ctx, org, memberID)
+	if found, diags := checkClientGet(ctx, err, d); !found {
+		return diags
 	}
 
 	sort.Strings(member.Teams)
@@ -129,7 +130,7 @@ func resourceSentryOrganizationMemberRead(ctx context.Context, d *schema.Resourc
 }
 
 func resourceSentryOrganizationMemberUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	org, memberID, err := splitSentryOrganizationMemberID(d.Id())
 	if err != nil {
@@ -156,7 +157,7 @@ func resourceSentryOrganizationMemberUpdate(ctx context.Context, d *schema.Resou
 
 	member, _, err := client.OrganizationMembers.Update(ctx, org, memberID, params)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIErr(err, resourceSentryOrganizationMember(), nil)
 	}
 
 	d.SetId(buildTwoPartID(org, member.ID))
@@ -164,7 +165,7 @@ func resourceSentryOrganizationMemberUpdate(ctx context.Context, d *schema.Resou
 }
 
 func resourceSentryOrganizationMemberDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	org, memberID, err := splitSentryOrganizationMemberID(d.Id())
 	if err != nil {
@@ -176,7 +177,7 @@ func resourceSentryOrganizationMemberDelete(ctx context.Context, d *schema.Resou
 		"memberID": memberID,
 	})
 	_, err = client.OrganizationMembers.Delete(ctx, org, memberID)
-	return diag.FromErr(err)
+	return checkClientDelete(ctx, err, d)
 }
 
 func splitSentryOrganizationMemberID(id string) (org string, memberID string, err error) {
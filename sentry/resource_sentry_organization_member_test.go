@@ -47,7 +47,7 @@ func TestAccSentryOrganizationMember_basic(t *testing.T) {
 }
 
 func testAccCheckSentryOrganizationMemberDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*sentry.Client)
+	client := testAccProvider.Meta().(*ProviderData).Client
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "sentry_organization_member" {
@@ -88,7 +88,7 @@ func testAccCheckSentryOrganizationMemberExists(n string, member *sentry.Organiz
 		if err != nil {
 			return err
 		}
-		client := testAccProvider.Meta().(*sentry.Client)
+		client := testAccProvider.Meta().(*ProviderData).Client
 		ctx := context.Background()
 		gotMember, _, err := client.OrganizationMembers.Get(ctx, org, id)
 
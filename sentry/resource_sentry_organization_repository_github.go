@@ -21,12 +21,14 @@ func resourceSentryOrganizationRepositoryGithub() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: importSentryOrganizationRepositoryGithub,
 		},
+		CustomizeDiff: customizeDiffOrganization,
 
 		Schema: map[string]*schema.Schema{
 			"organization": {
-				Description: "The slug of the Sentry organization this resource belongs to.",
+				Description: "The slug of the Sentry organization this resource belongs to. Defaults to the provider `organization`.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
 			},
 			"integration_id": {
@@ -50,8 +52,14 @@ func resourceSentryOrganizationRepositoryGithub() *schema.Resource {
 	}
 }
 
+// organizationRepositoryAPIFields maps the repository payload fields to their
+// attributes.
+var organizationRepositoryAPIFields = map[string]string{
+	"installation": "integration_id",
+}
+
 func resourceSentryOrganizationRepositoryGithubCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	org := d.Get("organization").(string)
 	integrationId := d.Get("integration_id").(string)
@@ -71,7 +79,7 @@ func resourceSentryOrganizationRepositoryGithubCreate(ctx context.Context, d *sc
 	}
 	orgRepo, _, err := client.OrganizationRepositories.Create(ctx, org, params)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIErr(err, resourceSentryOrganizationRepositoryGithub(), organizationRepositoryAPIFields)
 	}
 
 	tflog.Debug(ctx, "Created Sentry Github Organization Repository", map[string]interface{}{
@@ -90,7 +98,7 @@ func resourceSentryOrganizationRepositoryGithubCreate(ctx context.Context, d *sc
 }
 
 func resourceSentryOrganizationRepositoryGithubRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	id := d.Id()
 	org := d.Get("organization").(string)
@@ -109,8 +117,8 @@ func resourceSentryOrganizationRepositoryGithubRead(ctx context.Context, d *sche
 	}
 	for {
 		keys, resp, err := client.OrganizationRepositories.List(ctx, org, params)
-		if err != nil {
-			return diag.FromErr(err)
+		if found, diags := checkClientGet(ctx, err, d); !found {
+			return diags
 		}
 		orgRepos = append(orgRepos, keys...)
 
@@ -138,11 +146,16 @@ func resourceSentryOrganizationRepositoryGithubRead(ctx context.Context, d *sche
 		}
 	}
 
-	return diag.Errorf("Can't find Sentry Organization Repository: %s", id)
+	tflog.Warn(ctx, "Sentry Organization Repository not found, removing from state", map[string]interface{}{
+		"org": org,
+		"id":  id,
+	})
+	d.SetId("")
+	return nil
 }
 
 func resourceSentryOrganizationRepositoryGithubDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	id := d.Id()
 	org := d.Get("organization").(string)
@@ -160,11 +173,11 @@ func resourceSentryOrganizationRepositoryGithubDelete(ctx context.Context, d *sc
 		"internalId": internalId,
 	})
 
-	return diag.FromErr(err)
+	return checkClientDelete(ctx, err, d)
 }
 
 func importSentryOrganizationRepositoryGithub(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	org, id, err := splitTwoPartID(d.Id(), "organization-slug", "id")
+	org, id, err := splitTwoPartID(withDefaultOrganization(d.Id(), 2, meta), "organization-slug", "id")
 	if err != nil {
 		return nil, err
 	}
@@ -62,7 +62,7 @@ func TestAccSentryOrganization_basic(t *testing.T) {
 }
 
 func testAccCheckSentryOrganizationDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*sentry.Client)
+	client := testAccProvider.Meta().(*ProviderData).Client
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "sentry_organization" {
@@ -97,7 +97,7 @@ func testAccCheckSentryOrganizationExists(n string, organization *sentry.Organiz
 		}
 
 		org := rs.Primary.ID
-		client := testAccProvider.Meta().(*sentry.Client)
+		client := testAccProvider.Meta().(*ProviderData).Client
 		ctx := context.Background()
 		gotOrganization, _, err := client.Organizations.Get(ctx, org)
 		if err != nil {
@@ -117,3 +117,64 @@ resource "sentry_organization" "test_organization" {
 }
 	`, orgName)
 }
+
+func TestUnitSentryOrganization_basic(t *testing.T) {
+	p, _ := testUnitProvider(t)
+	r := newTestUnitResource(t, p, "sentry_organization").withConfig(map[string]interface{}{
+		"slug":        "tf-org",
+		"agree_terms": true,
+	})
+
+	r.step(map[string]interface{}{"name": "tf-org"}, map[string]string{
+		"id":   "tf-org",
+		"name": "tf-org",
+		"slug": "tf-org",
+	})
+	if r.state.Attributes["internal_id"] == "" {
+		t.Error("internal_id is not set")
+	}
+
+	r.apply(map[string]interface{}{"name": "tf-org-renamed"})
+	r.checkAttrs(map[string]string{
+		"id":   "tf-org",
+		"name": "tf-org-renamed",
+	})
+	r.checkImport("tf-org")
+
+	r.expectGone(r.destroy())
+}
+
+func TestUnitSentryOrganization_dataScrubbing(t *testing.T) {
+	p, _ := testUnitProvider(t)
+	r := newTestUnitResource(t, p, "sentry_organization").withConfig(map[string]interface{}{
+		"name":          "tf-org",
+		"slug":          "tf-org",
+		"agree_terms":   true,
+		"data_scrubber": false,
+		"safe_fields":   []interface{}{"order_id"},
+	})
+
+	r.step(nil, map[string]string{
+		"data_scrubber":          "false",
+		"data_scrubber_defaults": "true",
+		"safe_fields.#":          "1",
+		"safe_fields.0":          "order_id",
+		"rule.#":                 "0",
+	})
+
+	r.apply(map[string]interface{}{
+		"rule": []interface{}{
+			map[string]interface{}{
+				"type":   "ip",
+				"method": "remove",
+				"source": "$string",
+			},
+		},
+	})
+	r.checkAttrs(map[string]string{
+		"data_scrubber": "false",
+		"rule.#":        "1",
+		"rule.0.type":   "ip",
+	})
+	r.checkImport("tf-org")
+}
@@ -2,16 +2,18 @@ package sentry
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
-	"strings"
+	"net/url"
+	"strconv"
 
-	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/jianyuan/go-sentry/v2/sentry"
 )
 
@@ -25,62 +27,212 @@ func resourceSentryProject() *schema.Resource {
 		DeleteContext: resourceSentryProjectDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: importOrganizationAndID,
+			StateContext: importSentryProject,
 		},
+		CustomizeDiff: customizeDiffOrganization,
+
+		Schema:        resourceSentryProjectSchema(),
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceSentryProjectResourceV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceSentryProjectStateUpgradeV0,
+				Version: 0,
+			},
+		},
+	}
+}
 
+func resourceSentryProjectSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"organization": {
+			Description: "The slug of the organization the project belongs to. Defaults to the provider `organization`.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+		},
+		"team": {
+			Description:      "The slug of the team to create the project for. **Deprecated** Use `teams` instead.",
+			Type:             schema.TypeString,
+			Deprecated:       "Use `teams` instead.",
+			ConflictsWith:    []string{"teams"},
+			Optional:         true,
+			DiffSuppressFunc: suppressIgnoredTeamMembership,
+		},
+		"teams": {
+			Description: "The slugs of the teams to create the project for.",
+			Type:        schema.TypeSet,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			ConflictsWith:    []string{"team"},
+			Optional:         true,
+			DiffSuppressFunc: suppressIgnoredTeamMembership,
+		},
+		"ignore_team_membership": {
+			Description: "Whether the teams of the project are managed elsewhere, for instance with " +
+				"`sentry_project_team`. The teams are then only used to create the project, and neither " +
+				"changes to them nor teams added outside of this resource are applied.",
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"name": {
+			Description: "The name for the project.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"slug": {
+			Description: "The optional slug for this project.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+		},
+		"platform": {
+			Description: "The optional platform for this project.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+		},
+		"internal_id": {
+			Description: "The internal ID for this project. It is used to follow slug changes made outside of Terraform.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"is_public": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"is_bookmarked": {
+			Deprecated: "is_bookmarked is no longer used",
+			Type:       schema.TypeBool,
+			Computed:   true,
+		},
+		"color": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"features": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"status": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"digests_min_delay": {
+			Description: "The minimum amount of time (in seconds) to wait between scheduling digests for delivery after the initial scheduling.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Optional:    true,
+		},
+		"digests_max_delay": {
+			Description: "The maximum amount of time (in seconds) to wait between scheduling digests for delivery.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Optional:    true,
+		},
+		"resolve_age": {
+			Description: "Hours in which an issue is automatically resolve if not seen after this amount of time.",
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Computed:    true,
+		},
+		"project_id": {
+			Deprecated:  "Use `internal_id` instead.",
+			Description: "Use `internal_id` instead.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"options": {
+			Description: "Project options, such as `sentry:reprocessing_active` or `feedback:branding`, " +
+				"keyed by option name. Only the options set here are managed. The options known to be booleans " +
+				"or numbers, such as `sentry:scrape_javascript` or `sentry:resolve_age`, are converted; other " +
+				"values, including JSON documents such as `sentry:relay_pii_config`, are sent as strings. JSON " +
+				"values are compared semantically. " +
+				"Do not set the `filters:` options managed by `sentry_project_inbound_filters`, nor the options " +
+				"managed by `sentry_project_security` such as `sentry:scrape_javascript` and `sentry:origins`.",
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			DiffSuppressFunc: SuppressEquivalentJSONDiffs,
+		},
+		"default_rules": {
+			Description: "Whether Sentry creates its default issue alert, which notifies on every new issue, " +
+				"with the project. Only used when the project is created.",
+			Type:             schema.TypeBool,
+			Optional:         true,
+			Default:          true,
+			DiffSuppressFunc: SuppressAfterCreate,
+		},
+		"default_issue_alert": {
+			Description: "What to do with the default issue alert Sentry creates with the project, for instance " +
+				"when the server ignores `default_rules`: `adopt` records its ID in `default_issue_alert_id`, " +
+				"so that it can be imported into a `sentry_issue_alert`, and `delete` deletes it. Only used when " +
+				"the project is created.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateFunc:     validation.StringInSlice([]string{"adopt", "delete"}, false),
+			DiffSuppressFunc: SuppressAfterCreate,
+		},
+		"default_issue_alert_id": {
+			Description: "The ID of the default issue alert adopted with `default_issue_alert`.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+}
+
+// resourceSentryProjectResourceV0 is the schema of the state written before
+// the schema version was introduced.
+func resourceSentryProjectResourceV0() *schema.Resource {
+	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"organization": {
-				Description: "The slug of the organization the project belongs to.",
-				Type:        schema.TypeString,
-				Required:    true,
+				Type:     schema.TypeString,
+				Required: true,
 			},
 			"team": {
-				Description:   "The slug of the team to create the project for. **Deprecated** Use `teams` instead.",
-				Type:          schema.TypeString,
-				Deprecated:    "Use `teams` instead.",
-				ConflictsWith: []string{"teams"},
-				Optional:      true,
+				Type:     schema.TypeString,
+				Optional: true,
 			},
 			"teams": {
-				Description: "The slugs of the teams to create the project for.",
-				Type:        schema.TypeSet,
+				Type: schema.TypeSet,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
-				ConflictsWith: []string{"team"},
-				Optional:      true,
+				Optional: true,
 			},
 			"name": {
-				Description: "The name for the project.",
-				Type:        schema.TypeString,
-				Required:    true,
+				Type:     schema.TypeString,
+				Required: true,
 			},
 			"slug": {
-				Description: "The optional slug for this project.",
-				Type:        schema.TypeString,
-				Optional:    true,
-				Computed:    true,
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
 			},
 			"platform": {
-				Description:      "The optional platform for this project.",
-				Type:             schema.TypeString,
-				Optional:         true,
-				Computed:         true,
-				ValidateDiagFunc: validatePlatform,
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
 			},
 			"internal_id": {
-				Description: "The internal ID for this project.",
-				Type:        schema.TypeString,
-				Computed:    true,
+				Type:     schema.TypeString,
+				Computed: true,
 			},
 			"is_public": {
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
 			"is_bookmarked": {
-				Deprecated: "is_bookmarked is no longer used",
-				Type:       schema.TypeBool,
-				Computed:   true,
+				Type:     schema.TypeBool,
+				Computed: true,
 			},
 			"color": {
 				Type:     schema.TypeString,
@@ -98,36 +250,43 @@ func resourceSentryProject() *schema.Resource {
 				Computed: true,
 			},
 			"digests_min_delay": {
-				Description: "The minimum amount of time (in seconds) to wait between scheduling digests for delivery after the initial scheduling.",
-				Type:        schema.TypeInt,
-				Computed:    true,
-				Optional:    true,
+				Type:     schema.TypeInt,
+				Computed: true,
+				Optional: true,
 			},
 			"digests_max_delay": {
-				Description: "The maximum amount of time (in seconds) to wait between scheduling digests for delivery.",
-				Type:        schema.TypeInt,
-				Computed:    true,
-				Optional:    true,
+				Type:     schema.TypeInt,
+				Computed: true,
+				Optional: true,
 			},
 			"resolve_age": {
-				Description: "Hours in which an issue is automatically resolve if not seen after this amount of time.",
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Computed:    true,
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
 			},
 			"project_id": {
-				Deprecated:  "Use `internal_id` instead.",
-				Description: "Use `internal_id` instead.",
-				Type:        schema.TypeString,
-				Computed:    true,
+				Type:     schema.TypeString,
+				Computed: true,
 			},
-			// TODO: Project options
 		},
 	}
 }
 
+// resourceSentryProjectStateUpgradeV0 gives the attributes added since v0
+// their defaults. The internal ID, which is used to follow slug changes, is
+// recorded by the next Read if missing.
+func resourceSentryProjectStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if _, ok := rawState["default_rules"]; !ok {
+		rawState["default_rules"] = true
+	}
+	if _, ok := rawState["ignore_team_membership"]; !ok {
+		rawState["ignore_team_membership"] = false
+	}
+	return rawState, nil
+}
+
 func resourceSentryProjectCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	org := d.Get("organization").(string)
 
@@ -147,9 +306,12 @@ func resourceSentryProjectCreate(ctx context.Context, d *schema.ResourceData, me
 		initialTeam = teams.(*schema.Set).List()[0].(string)
 	}
 
-	params := &sentry.CreateProjectParams{
-		Name: d.Get("name").(string),
-		Slug: d.Get("slug").(string),
+	params := &createProjectParams{
+		CreateProjectParams: sentry.CreateProjectParams{
+			Name: d.Get("name").(string),
+			Slug: d.Get("slug").(string),
+		},
+		DefaultRules: sentry.Bool(d.Get("default_rules").(bool)),
 	}
 
 	tflog.Debug(ctx, "Creating Sentry project", map[string]interface{}{
@@ -158,9 +320,9 @@ func resourceSentryProjectCreate(ctx context.Context, d *schema.ResourceData, me
 		"org":         org,
 		"initialTeam": initialTeam,
 	})
-	proj, _, err := client.Projects.Create(ctx, org, initialTeam, params)
+	proj, err := createProject(ctx, client, org, initialTeam, params)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIErr(err, resourceSentryProject(), nil)
 	}
 	tflog.Debug(ctx, "Created Sentry project", map[string]interface{}{
 		"projectSlug": proj.Slug,
@@ -170,11 +332,98 @@ func resourceSentryProjectCreate(ctx context.Context, d *schema.ResourceData, me
 	})
 
 	d.SetId(proj.Slug)
+	if action, ok := d.GetOk("default_issue_alert"); ok {
+		if diags := handleDefaultIssueAlerts(ctx, client, d, org, proj.Slug, action.(string)); diags.HasError() {
+			return diags
+		}
+	}
 	return resourceSentryProjectUpdate(ctx, d, meta)
 }
 
+// findProjectByID returns the project of an organization with the given
+// internal ID, or nil if there is none.
+func findProjectByID(ctx context.Context, client *sentry.Client, org, id string) (*sentry.Project, error) {
+	query := url.Values{"query": {"id:" + id}}
+	for {
+		req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("0/organizations/%v/projects/?%s", org, query.Encode()), nil)
+		if err != nil {
+			return nil, err
+		}
+		var projects []*sentry.Project
+		resp, err := client.Do(ctx, req, &projects)
+		if err != nil {
+			return nil, err
+		}
+		for _, proj := range projects {
+			if proj.ID == id {
+				return proj, nil
+			}
+		}
+		if resp.Cursor == "" {
+			return nil, nil
+		}
+		query.Set("cursor", resp.Cursor)
+	}
+}
+
+// suppressIgnoredTeamMembership suppresses changes to the teams of a project
+// whose team membership is managed elsewhere.
+func suppressIgnoredTeamMembership(k, old, new string, d *schema.ResourceData) bool {
+	return d.Id() != "" && d.Get("ignore_team_membership").(bool)
+}
+
+// createProjectParams adds the flag go-sentry lacks to skip the default issue
+// alert.
+type createProjectParams struct {
+	sentry.CreateProjectParams
+	DefaultRules *bool `json:"default_rules,omitempty"`
+}
+
+func createProject(ctx context.Context, client *sentry.Client, org, team string, params *createProjectParams) (*sentry.Project, error) {
+	req, err := client.NewRequest(http.MethodPost, fmt.Sprintf("0/teams/%v/%v/projects/", org, team), params)
+	if err != nil {
+		return nil, err
+	}
+	proj := new(sentry.Project)
+	if _, err := client.Do(ctx, req, proj); err != nil {
+		return nil, err
+	}
+	return proj, nil
+}
+
+// handleDefaultIssueAlerts adopts or deletes the issue alerts of a project
+// that has just been created, which are those Sentry creates by default.
+func handleDefaultIssueAlerts(ctx context.Context, client *sentry.Client, d *schema.ResourceData, org, project, action string) diag.Diagnostics {
+	alerts, _, err := client.IssueAlerts.List(ctx, org, project, nil)
+	if err != nil {
+		return diagFromAPIErr(err, nil, nil)
+	}
+
+	for _, alert := range alerts {
+		id := sentry.StringValue(alert.ID)
+		tflog.Debug(ctx, "Handling default issue alert", map[string]interface{}{
+			"org":     org,
+			"project": project,
+			"alertID": id,
+			"action":  action,
+		})
+		switch action {
+		case "adopt":
+			if err := d.Set("default_issue_alert_id", id); err != nil {
+				return diag.FromErr(err)
+			}
+			return nil
+		case "delete":
+			if _, err := client.IssueAlerts.Delete(ctx, org, project, id); err != nil && !isNotFoundError(err) {
+				return diagFromAPIErr(err, nil, nil)
+			}
+		}
+	}
+	return nil
+}
+
 func resourceSentryProjectRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	slug := d.Id()
 	org := d.Get("organization").(string)
@@ -183,9 +432,26 @@ func resourceSentryProjectRead(ctx context.Context, d *schema.ResourceData, meta
 		"projectSlug": slug,
 		"org":         org,
 	})
-	proj, resp, err := client.Projects.Get(ctx, org, slug)
-	if found, err := checkClientGet(resp, err, d); !found {
-		return diag.FromErr(err)
+	proj, _, err := client.Projects.Get(ctx, org, slug)
+	if id := d.Get("internal_id").(string); isNotFoundError(err) && id != "" {
+		// The slug may have been changed outside of Terraform, so look the
+		// project up by its immutable ID.
+		renamed, findErr := findProjectByID(ctx, client, org, id)
+		if findErr != nil {
+			return diagFromAPIErr(findErr, nil, nil)
+		}
+		if renamed != nil {
+			tflog.Info(ctx, "Sentry project slug changed outside of Terraform", map[string]interface{}{
+				"org":         org,
+				"projectID":   id,
+				"oldSlug":     slug,
+				"projectSlug": renamed.Slug,
+			})
+			proj, err = renamed, nil
+		}
+	}
+	if found, diags := checkClientGet(ctx, err, d); !found {
+		return diags
 	}
 	tflog.Debug(ctx, "Read Sentry project", map[string]interface{}{
 		"projectSlug": proj.Slug,
@@ -209,23 +475,24 @@ func resourceSentryProjectRead(ctx context.Context, d *schema.ResourceData, meta
 		d.Set("resolve_age", proj.ResolveAge),
 		d.Set("project_id", proj.ID), // Deprecated
 	)
-	if _, ok := d.GetOk("team"); ok {
-		retErr = multierror.Append(retErr, d.Set("team", proj.Team.Slug))
-	} else {
-		teams := make([]string, 0, len(proj.Teams))
-		for _, team := range proj.Teams {
-			teams = append(teams, *team.Slug)
+	if !d.Get("ignore_team_membership").(bool) {
+		if _, ok := d.GetOk("team"); ok {
+			retErr = multierror.Append(retErr, d.Set("team", proj.Team.Slug))
+		} else {
+			teams := make([]string, 0, len(proj.Teams))
+			for _, team := range proj.Teams {
+				teams = append(teams, *team.Slug)
+			}
+			retErr = multierror.Append(retErr, d.Set("teams", flattenStringSet(teams)))
 		}
-		retErr = multierror.Append(retErr, d.Set("teams", flattenStringSet(teams)))
 	}
-
-	// TODO: Project options
+	retErr = multierror.Append(retErr, d.Set("options", flattenProjectOptions(proj.Options, d.Get("options").(map[string]interface{}))))
 
 	return diag.FromErr(retErr.ErrorOrNil())
 }
 
 func resourceSentryProjectUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	project := d.Id()
 	org := d.Get("organization").(string)
@@ -238,6 +505,7 @@ func resourceSentryProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 	if platform != "" {
 		params.Platform = platform
 	}
+	warnings := platformWarnings(ctx, d, meta)
 
 	if v, ok := d.GetOk("digests_min_delay"); ok {
 		params.DigestsMinDelay = sentry.Int(v.(int))
@@ -251,17 +519,33 @@ func resourceSentryProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 		params.ResolveAge = sentry.Int(v.(int))
 	}
 
+	if d.HasChange("options") {
+		o, n := d.GetChange("options")
+		options, err := expandProjectOptions(o.(map[string]interface{}), n.(map[string]interface{}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		params.Options = options
+	}
+
 	tflog.Debug(ctx, "Updating project", map[string]interface{}{
 		"org":     org,
 		"project": project,
 	})
 	proj, _, err := client.Projects.Update(ctx, org, project, params)
 	if err != nil {
-		return diag.FromErr(err)
+		return append(warnings, diagFromAPIErr(err, resourceSentryProject(), nil)...)
 	}
 
+	// The slug may have changed, so subsequent calls must use the new one.
+	project = proj.Slug
 	d.SetId(proj.Slug)
 
+	// Teams managed elsewhere are only added with the project.
+	if d.Get("ignore_team_membership").(bool) && !d.IsNewResource() {
+		return append(warnings, resourceSentryProjectRead(ctx, d, meta)...)
+	}
+
 	oldTeams := map[string]struct{}{}
 	newTeams := map[string]struct{}{}
 	if d.HasChange("team") {
@@ -303,7 +587,7 @@ func resourceSentryProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 		for newTeam := range newTeams {
 			_, _, err = client.Projects.AddTeam(ctx, org, project, newTeam)
 			if err != nil {
-				return diag.FromErr(err)
+				return diagFromAPIErr(err, resourceSentryProject(), nil)
 			}
 		}
 	}
@@ -316,20 +600,18 @@ func resourceSentryProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 		})
 
 		for oldTeam := range oldTeams {
-			resp, err := client.Projects.RemoveTeam(ctx, org, project, oldTeam)
-			if err != nil {
-				if resp.Response.StatusCode != http.StatusNotFound {
-					return diag.FromErr(err)
-				}
+			_, err := client.Projects.RemoveTeam(ctx, org, project, oldTeam)
+			if err != nil && !isNotFoundError(err) {
+				return diagFromAPIErr(err, nil, nil)
 			}
 		}
 	}
 
-	return resourceSentryProjectRead(ctx, d, meta)
+	return append(warnings, resourceSentryProjectRead(ctx, d, meta)...)
 }
 
 func resourceSentryProjectDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	slug := d.Id()
 	org := d.Get("organization").(string)
@@ -344,47 +626,82 @@ func resourceSentryProjectDelete(ctx context.Context, d *schema.ResourceData, me
 		"org":         org,
 	})
 
-	return diag.FromErr(err)
+	return checkClientDelete(ctx, err, d)
 }
 
-func validatePlatform(i interface{}, path cty.Path) diag.Diagnostics {
-	var diagnostics diag.Diagnostics
+// projectOptionTypes holds the project options known to be booleans or
+// numbers. The other options are sent as strings, as written.
+var projectOptionTypes = map[string]schema.ValueType{
+	"feedback:branding":                      schema.TypeBool,
+	"filters:chunk-load-error":               schema.TypeBool,
+	"filters:react-hydration-errors":         schema.TypeBool,
+	"quotas:spike-protection-disabled":       schema.TypeBool,
+	"sentry:csp_ignored_sources_defaults":    schema.TypeBool,
+	"sentry:grouping_auto_update":            schema.TypeBool,
+	"sentry:replay_rage_click_issues":        schema.TypeBool,
+	"sentry:reprocessing_active":             schema.TypeBool,
+	"sentry:scrape_javascript":               schema.TypeBool,
+	"sentry:scrub_data":                      schema.TypeBool,
+	"sentry:scrub_defaults":                  schema.TypeBool,
+	"sentry:verify_ssl":                      schema.TypeBool,
+	"digests:mail:maximum_delay":             schema.TypeInt,
+	"digests:mail:minimum_delay":             schema.TypeInt,
+	"sentry:resolve_age":                     schema.TypeInt,
+	"sentry:secondary_grouping_expiry":       schema.TypeInt,
+	"sentry:store_crash_reports":             schema.TypeInt,
+	"sentry:performance_issue_creation_rate": schema.TypeFloat,
+}
 
-	v := i.(string)
-	if v == "other" {
-		return nil
+// expandProjectOptions returns the options to update from the old and new
+// options of a project. Options that are no longer set are reset.
+func expandProjectOptions(old, new map[string]interface{}) (map[string]interface{}, error) {
+	options := make(map[string]interface{}, len(old)+len(new))
+	for k := range old {
+		options[k] = nil
 	}
-
-	urls := []string{
-		fmt.Sprintf("https://docs.sentry.io/_platforms/%s.json", v),
-		fmt.Sprintf(
-			"https://docs.sentry.io/_platforms/%s.json",
-			strings.Replace(v, "-", "/", 1),
-		),
+	for k, v := range new {
+		value, err := expandProjectOption(k, v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of option %s: %w", k, err)
+		}
+		options[k] = value
 	}
+	return options, nil
+}
 
-	for _, url := range urls {
-		resp, err := http.Get(url)
+// expandProjectOption converts the value of an option to the type Sentry
+// expects for it.
+func expandProjectOption(k, v string) (interface{}, error) {
+	switch projectOptionTypes[k] {
+	case schema.TypeBool:
+		return strconv.ParseBool(v)
+	case schema.TypeInt:
+		return strconv.Atoi(v)
+	case schema.TypeFloat:
+		return strconv.ParseFloat(v, 64)
+	}
+	return v, nil
+}
 
-		if err != nil {
-			msg := "could not validate the platform at this time"
-			diagnostics = append(diagnostics, diag.Diagnostic{
-				Severity:      diag.Error,
-				Summary:       msg,
-				Detail:        msg,
-				AttributePath: path,
-			})
-		} else if resp.StatusCode == 200 {
-			return nil
+// flattenProjectOptions returns the options of a project that are tracked,
+// as strings.
+func flattenProjectOptions(options map[string]interface{}, tracked map[string]interface{}) map[string]string {
+	flattened := make(map[string]string, len(tracked))
+	for k := range tracked {
+		v, ok := options[k]
+		if !ok || v == nil {
+			continue
+		}
+		switch v := v.(type) {
+		case string:
+			flattened[k] = v
+		default:
+			b, err := json.Marshal(v)
+			if err != nil {
+				continue
+			}
+			flattened[k] = string(b)
 		}
 	}
-
-	msg := fmt.Sprintf("%s is not a valid platform", v)
-	diagnostics = append(diagnostics, diag.Diagnostic{
-		Severity:      diag.Error,
-		Summary:       msg,
-		Detail:        msg,
-		AttributePath: path,
-	})
-	return diagnostics
+	return flattened
 }
@@ -0,0 +1,252 @@
+package sentry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+// codeownersErrors describes the errors Sentry reports when parsing
+// CODEOWNERS content, in the order they are reported.
+var codeownersErrors = []struct {
+	key     string
+	summary string
+}{
+	{"missing_user_emails", "CODEOWNERS emails without a Sentry user"},
+	{"missing_external_users", "CODEOWNERS users without a Sentry association"},
+	{"missing_external_teams", "CODEOWNERS teams without a Sentry association"},
+	{"teams_without_access", "CODEOWNERS teams without access to the project"},
+	{"users_without_access", "CODEOWNERS users without access to the project"},
+}
+
+func resourceSentryProjectCodeowners() *schema.Resource {
+	return &schema.Resource{
+		Description: "Sentry Project CODEOWNERS resource. It syncs the CODEOWNERS file of the repository of a " +
+			"code mapping to the issue owners of its project.",
+
+		CreateContext: resourceSentryProjectCodeownersCreate,
+		ReadContext:   resourceSentryProjectCodeownersRead,
+		UpdateContext: resourceSentryProjectCodeownersUpdate,
+		DeleteContext: resourceSentryProjectCodeownersDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importOrganizationProjectScopedID,
+		},
+		CustomizeDiff: customizeDiffOrganization,
+
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Description: "The slug of the organization the project belongs to. Defaults to the provider `organization`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"project": {
+				Description: "The slug of the project the code mapping belongs to.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"code_mapping_id": {
+				Description: "The ID of the code mapping of the repository the CODEOWNERS file comes from, such as " +
+					"the `id` of a `sentry_organization_code_mapping`.",
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"raw": {
+				Description: "The content of the CODEOWNERS file. Owners Sentry cannot resolve are reported as warnings.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"internal_id": {
+				Description: "The internal ID of the CODEOWNERS entry.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"provider_name": {
+				Description: "The provider of the integration of the code mapping, such as `github`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"date_updated": {
+				Description: "When the CODEOWNERS entry was last updated.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceSentryProjectCodeownersCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderData).ClientWithContext(ctx)
+
+	org := d.Get("organization").(string)
+	project := d.Get("project").(string)
+	params := &projectCodeownersParams{
+		Raw:           d.Get("raw").(string),
+		CodeMappingID: d.Get("code_mapping_id").(string),
+	}
+
+	tflog.Debug(ctx, "Creating project CODEOWNERS", map[string]interface{}{
+		"org":           org,
+		"project":       project,
+		"codeMappingId": params.CodeMappingID,
+	})
+	codeowners, err := requestProjectCodeowners(ctx, client, http.MethodPost, fmt.Sprintf("0/projects/%v/%v/codeowners/", org, project), params)
+	if err != nil {
+		return diagFromAPIErr(err, resourceSentryProjectCodeowners(), map[string]string{"codeMappingId": "code_mapping_id"})
+	}
+
+	d.SetId(buildThreePartID(org, project, codeowners.ID))
+	return append(codeownersWarnings(codeowners), resourceSentryProjectCodeownersRead(ctx, d, meta)...)
+}
+
+func resourceSentryProjectCodeownersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderData).ClientWithContext(ctx)
+
+	org, project, id, err := splitThreePartID(d.Id(), "organization-slug", "project-slug", "codeowners-id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Debug(ctx, "Reading project CODEOWNERS", map[string]interface{}{
+		"org":     org,
+		"project": project,
+		"id":      id,
+	})
+	req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("0/projects/%v/%v/codeowners/", org, project), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var list []*projectCodeowners
+	_, err = client.Do(ctx, req, &list)
+	if found, diags := checkClientGet(ctx, err, d); !found {
+		return diags
+	}
+
+	for _, codeowners := range list {
+		if codeowners.ID == id {
+			retErr := multierror.Append(
+				d.Set("organization", org),
+				d.Set("project", project),
+				d.Set("code_mapping_id", codeowners.CodeMappingID),
+				d.Set("raw", codeowners.Raw),
+				d.Set("internal_id", codeowners.ID),
+				d.Set("provider_name", codeowners.Provider),
+				d.Set("date_updated", codeowners.DateUpdated.Format(time.RFC3339)),
+			)
+			return diag.FromErr(retErr.ErrorOrNil())
+		}
+	}
+
+	tflog.Warn(ctx, "Sentry project CODEOWNERS not found, removing from state", map[string]interface{}{
+		"org":     org,
+		"project": project,
+		"id":      id,
+	})
+	d.SetId("")
+	return nil
+}
+
+func resourceSentryProjectCodeownersUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderData).ClientWithContext(ctx)
+
+	org, project, id, err := splitThreePartID(d.Id(), "organization-slug", "project-slug", "codeowners-id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	params := &projectCodeownersParams{
+		Raw:           d.Get("raw").(string),
+		CodeMappingID: d.Get("code_mapping_id").(string),
+	}
+
+	tflog.Debug(ctx, "Updating project CODEOWNERS", map[string]interface{}{
+		"org":     org,
+		"project": project,
+		"id":      id,
+	})
+	codeowners, err := requestProjectCodeowners(ctx, client, http.MethodPut, fmt.Sprintf("0/projects/%v/%v/codeowners/%v/", org, project, id), params)
+	if err != nil {
+		return diagFromAPIErr(err, resourceSentryProjectCodeowners(), map[string]string{"codeMappingId": "code_mapping_id"})
+	}
+
+	return append(codeownersWarnings(codeowners), resourceSentryProjectCodeownersRead(ctx, d, meta)...)
+}
+
+func resourceSentryProjectCodeownersDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderData).ClientWithContext(ctx)
+
+	org, project, id, err := splitThreePartID(d.Id(), "organization-slug", "project-slug", "codeowners-id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Debug(ctx, "Deleting project CODEOWNERS", map[string]interface{}{
+		"org":     org,
+		"project": project,
+		"id":      id,
+	})
+	req, err := client.NewRequest(http.MethodDelete, fmt.Sprintf("0/projects/%v/%v/codeowners/%v/", org, project, id), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, err = client.Do(ctx, req, nil)
+	return checkClientDelete(ctx, err, d)
+}
+
+// projectCodeowners is a CODEOWNERS entry of a project, which go-sentry
+// does not support.
+type projectCodeowners struct {
+	ID            string              `json:"id"`
+	Raw           string              `json:"raw"`
+	CodeMappingID string              `json:"codeMappingId"`
+	Provider      string              `json:"provider"`
+	DateUpdated   time.Time           `json:"dateUpdated"`
+	Errors        map[string][]string `json:"errors"`
+}
+
+type projectCodeownersParams struct {
+	Raw           string `json:"raw"`
+	CodeMappingID string `json:"codeMappingId"`
+}
+
+func requestProjectCodeowners(ctx context.Context, client *sentry.Client, method, url string, params *projectCodeownersParams) (*projectCodeowners, error) {
+	req, err := client.NewRequest(method, url, params)
+	if err != nil {
+		return nil, err
+	}
+	codeowners := new(projectCodeowners)
+	if _, err := client.Do(ctx, req, codeowners); err != nil {
+		return nil, err
+	}
+	return codeowners, nil
+}
+
+// codeownersWarnings reports the owners Sentry could not resolve when
+// parsing CODEOWNERS content.
+func codeownersWarnings(codeowners *projectCodeowners) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, e := range codeownersErrors {
+		if values := codeowners.Errors[e.key]; len(values) > 0 {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  e.summary,
+				Detail: fmt.Sprintf(
+					"Sentry cannot resolve these owners, so they will not be assigned issues: %s.",
+					strings.Join(values, ", "),
+				),
+				AttributePath: cty.GetAttrPath("raw"),
+			})
+		}
+	}
+	return diags
+}
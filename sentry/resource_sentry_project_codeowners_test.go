@@ -0,0 +1,51 @@
+package sentry
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnitSentryProjectCodeowners_basic(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+	srv.AddProject(testUnitOrganization, "team", "tf-project")
+	mappingID := srv.AddCodeMapping(testUnitOrganization, "tf-project")
+	base := map[string]interface{}{
+		"organization":    testUnitOrganization,
+		"project":         "tf-project",
+		"code_mapping_id": mappingID,
+	}
+	r := newTestUnitResource(t, p, "sentry_project_codeowners").withConfig(base)
+
+	diags := r.apply(map[string]interface{}{"raw": "# Owners\n*.go @example/backend\ndocs/ jane@example.com\n"})
+	r.checkAttrs(map[string]string{
+		"code_mapping_id": mappingID,
+		"raw":             "# Owners\n*.go @example/backend\ndocs/ jane@example.com\n",
+		"provider_name":   "github",
+	})
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 warnings, got %v", diags)
+	}
+	if got := diags[0].Summary; got != "CODEOWNERS emails without a Sentry user" || !strings.Contains(diags[0].Detail, "jane@example.com") {
+		t.Errorf("unexpected first warning: %s: %s", got, diags[0].Detail)
+	}
+	if got := diags[1].Summary; got != "CODEOWNERS teams without a Sentry association" || !strings.Contains(diags[1].Detail, "@example/backend") {
+		t.Errorf("unexpected second warning: %s: %s", got, diags[1].Detail)
+	}
+
+	r.apply(map[string]interface{}{"raw": "* @example/backend\n"})
+	r.checkAttrs(map[string]string{
+		"raw": "* @example/backend\n",
+	})
+
+	r.checkImport(r.state.ID)
+
+	// A code mapping can only have one CODEOWNERS entry.
+	other := newTestUnitResource(t, p, "sentry_project_codeowners").withConfig(base)
+	diags = other.expectApplyError(map[string]interface{}{"raw": "* @example/frontend\n"})
+	if len(diags) != 1 || diags[0].Detail != "This code mapping is already in use." {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+
+	r.expectGone(r.destroy())
+}
@@ -0,0 +1,131 @@
+package sentry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+// dataScrubbingAPIFields maps the data scrubbing fields to their attributes.
+var dataScrubbingAPIFields = map[string]string{
+	"relayPiiConfig": "rule",
+}
+
+func resourceSentryProjectDataScrubbing() *schema.Resource {
+	s := dataScrubbingSchema(true)
+	s["organization"] = &schema.Schema{
+		Description: "The slug of the organization the project belongs to. Defaults to the provider `organization`.",
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+	}
+	s["project"] = &schema.Schema{
+		Description: "The slug of the project to scrub the data of.",
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+	}
+
+	return &schema.Resource{
+		Description: "Sentry Project Data Scrubbing resource. It manages the security and privacy settings of a " +
+			"project that remove sensitive data from events, and resets them when deleted.",
+
+		CreateContext: resourceSentryProjectDataScrubbingUpdate,
+		ReadContext:   resourceSentryProjectDataScrubbingRead,
+		UpdateContext: resourceSentryProjectDataScrubbingUpdate,
+		DeleteContext: resourceSentryProjectDataScrubbingDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importOrganizationScopedID,
+		},
+		CustomizeDiff: customdiff.All(
+			customizeDiffOrganization,
+			customizeDiffDataScrubbingRules,
+		),
+
+		Schema: s,
+	}
+}
+
+func resourceSentryProjectDataScrubbingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderData).ClientWithContext(ctx)
+
+	org, project, err := splitTwoPartID(d.Id(), "organization-slug", "project-slug")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Debug(ctx, "Reading project data scrubbing", map[string]interface{}{
+		"org":     org,
+		"project": project,
+	})
+	settings := new(dataScrubbing)
+	err = requestDataScrubbing(ctx, client, http.MethodGet, fmt.Sprintf("0/projects/%v/%v/", org, project), nil, settings)
+	if found, diags := checkClientGet(ctx, err, d); !found {
+		return diags
+	}
+
+	retErr := multierror.Append(
+		d.Set("organization", org),
+		d.Set("project", project),
+		flattenDataScrubbing(d, settings),
+	)
+	return diag.FromErr(retErr.ErrorOrNil())
+}
+
+func resourceSentryProjectDataScrubbingUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderData).ClientWithContext(ctx)
+
+	org := d.Get("organization").(string)
+	project := d.Get("project").(string)
+	settings, err := expandDataScrubbing(d, func(string) bool { return true })
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Debug(ctx, "Updating project data scrubbing", map[string]interface{}{
+		"org":     org,
+		"project": project,
+	})
+	err = requestDataScrubbing(ctx, client, http.MethodPut, fmt.Sprintf("0/projects/%v/%v/", org, project), settings, nil)
+	if err != nil {
+		return diagFromAPIErr(err, resourceSentryProjectDataScrubbing(), dataScrubbingAPIFields)
+	}
+
+	d.SetId(buildTwoPartID(org, project))
+	return resourceSentryProjectDataScrubbingRead(ctx, d, meta)
+}
+
+func resourceSentryProjectDataScrubbingDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderData).ClientWithContext(ctx)
+
+	org, project, err := splitTwoPartID(d.Id(), "organization-slug", "project-slug")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Debug(ctx, "Resetting project data scrubbing", map[string]interface{}{
+		"org":     org,
+		"project": project,
+	})
+	err = requestDataScrubbing(ctx, client, http.MethodPut, fmt.Sprintf("0/projects/%v/%v/", org, project), defaultDataScrubbing(), nil)
+	return checkClientDelete(ctx, err, d)
+}
+
+// requestDataScrubbing sends a request with the data scrubbing settings of a
+// project or an organization, decoding the settings of the response into v.
+func requestDataScrubbing(ctx context.Context, client *sentry.Client, method, url string, body, v interface{}) error {
+	req, err := client.NewRequest(method, url, body)
+	if err != nil {
+		return err
+	}
+	_, err = client.Do(ctx, req, v)
+	return err
+}
@@ -0,0 +1,172 @@
+package sentry
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUnitSentryProjectDataScrubbing_basic(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+	srv.AddProject(testUnitOrganization, "team", "tf-project")
+	r := newTestUnitResource(t, p, "sentry_project_data_scrubbing").withConfig(map[string]interface{}{
+		"organization":       testUnitOrganization,
+		"project":            "tf-project",
+		"scrub_ip_addresses": true,
+		"sensitive_fields":   []interface{}{"ssn", "api_token"},
+	})
+
+	rules := func(method string) map[string]interface{} {
+		return map[string]interface{}{
+			"rule": []interface{}{
+				map[string]interface{}{
+					"type":   "creditcard",
+					"method": method,
+					"source": "$message",
+				},
+				map[string]interface{}{
+					"type":        "pattern",
+					"method":      "replace",
+					"source":      "$http.headers.x-auth",
+					"pattern":     "[a-f0-9]{32}",
+					"replacement": "[token]",
+				},
+			},
+		}
+	}
+
+	r.step(rules("mask"), map[string]string{
+		"id":                     buildTwoPartID(testUnitOrganization, "tf-project"),
+		"data_scrubber":          "true",
+		"data_scrubber_defaults": "true",
+		"scrub_ip_addresses":     "true",
+		"sensitive_fields.#":     "2",
+		"sensitive_fields.1":     "api_token",
+		"safe_fields.#":          "0",
+		"rule.#":                 "2",
+		"rule.0.type":            "creditcard",
+		"rule.0.method":          "mask",
+		"rule.0.source":          "$message",
+		"rule.1.pattern":         "[a-f0-9]{32}",
+		"rule.1.replacement":     "[token]",
+	})
+
+	r.apply(rules("hash"))
+	r.checkAttrs(map[string]string{
+		"rule.0.method": "hash",
+	})
+
+	// Rules changed outside of Terraform are detected.
+	srv.SetProjectRelayPiiConfig(testUnitOrganization, "tf-project",
+		`{"rules":{"a":{"type":"email","redaction":{"method":"remove"}}},"applications":{"$string":["a"],"$frame.vars":["a"]}}`)
+	r.refresh()
+	r.checkAttrs(map[string]string{
+		"rule.#":        "2",
+		"rule.0.type":   "email",
+		"rule.0.source": "$frame.vars",
+		"rule.1.source": "$string",
+	})
+
+	r.apply(rules("mask"))
+	r.checkImport(buildTwoPartID(testUnitOrganization, "tf-project"))
+
+	// Deleting the resource resets the settings.
+	prev := r.destroy()
+	refreshed := r.importState(prev.ID)
+	for k, want := range map[string]string{
+		"scrub_ip_addresses": "false",
+		"sensitive_fields.#": "0",
+		"rule.#":             "0",
+	} {
+		if got := refreshed.Attributes[k]; got != want {
+			t.Errorf("attribute %s after destroy: got %q, want %q", k, got, want)
+		}
+	}
+}
+
+func TestUnitSentryProjectDataScrubbing_invalidRule(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+	srv.AddProject(testUnitOrganization, "team", "tf-project")
+	r := newTestUnitResource(t, p, "sentry_project_data_scrubbing").withConfig(map[string]interface{}{
+		"organization": testUnitOrganization,
+		"project":      "tf-project",
+	})
+
+	rule := func(attrs map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{"rule": []interface{}{attrs}}
+	}
+	r.expectPlanError(rule(map[string]interface{}{
+		"type":   "pattern",
+		"method": "remove",
+		"source": "$string",
+	}), "rule 0: pattern is required by pattern rules")
+	r.expectPlanError(rule(map[string]interface{}{
+		"type":    "email",
+		"method":  "remove",
+		"source":  "$string",
+		"pattern": ".*",
+	}), "rule 0: pattern is only used by pattern rules")
+	r.expectPlanError(rule(map[string]interface{}{
+		"type":        "email",
+		"method":      "mask",
+		"source":      "$string",
+		"replacement": "[email]",
+	}), "rule 0: replacement is only used by the replace method")
+	r.expectPlanError(rule(map[string]interface{}{
+		"type":   "redactPair",
+		"method": "remove",
+		"source": "$string",
+		"extra":  `{"type":"email"}`,
+	}), "rule 0: extra cannot set type")
+}
+
+func TestUnitSentryProjectDataScrubbing_otherRuleTypes(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+	srv.AddProject(testUnitOrganization, "team", "tf-project")
+	r := newTestUnitResource(t, p, "sentry_project_data_scrubbing").withConfig(map[string]interface{}{
+		"organization": testUnitOrganization,
+		"project":      "tf-project",
+	})
+
+	// Rules of types without their own attributes are read with their
+	// fields in extra.
+	srv.SetProjectRelayPiiConfig(testUnitOrganization, "tf-project",
+		`{"rules":{"0":{"type":"redactPair","keyPattern":"^secret$","redaction":{"method":"replace","text":"[redacted]"}}},"applications":{"$string":["0"]}}`)
+	state := r.importState(buildTwoPartID(testUnitOrganization, "tf-project"))
+	for k, want := range map[string]string{
+		"rule.#":             "1",
+		"rule.0.type":        "redactPair",
+		"rule.0.replacement": "[redacted]",
+		"rule.0.extra":       `{"keyPattern":"^secret$"}`,
+	} {
+		if got := state.Attributes[k]; got != want {
+			t.Errorf("attribute %s: got %q, want %q", k, got, want)
+		}
+	}
+
+	r.step(map[string]interface{}{
+		"rule": []interface{}{
+			map[string]interface{}{
+				"type":        "redactPair",
+				"method":      "replace",
+				"source":      "$string",
+				"replacement": "[redacted]",
+				"extra":       `{ "keyPattern": "^token$" }`,
+			},
+		},
+	}, map[string]string{
+		"rule.0.extra": `{"keyPattern":"^token$"}`,
+	})
+	r.checkImport(buildTwoPartID(testUnitOrganization, "tf-project"))
+
+	// The rules are sent with the fields in extra.
+	var relayPiiConfig relayPiiConfig
+	if err := json.Unmarshal([]byte(srv.ProjectRelayPiiConfig(testUnitOrganization, "tf-project")), &relayPiiConfig); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(relayPiiConfig.Rules["0"].Extra["keyPattern"]); got != `"^token$"` {
+		t.Errorf("unexpected keyPattern: %s", got)
+	}
+}
@@ -0,0 +1,332 @@
+package sentry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+// fingerprintingMatchers are the matchers of fingerprint rules. Tags are
+// matched with tags.<key>.
+var fingerprintingMatchers = map[string]bool{
+	"error.type":     true,
+	"type":           true,
+	"error.value":    true,
+	"value":          true,
+	"message":        true,
+	"logger":         true,
+	"level":          true,
+	"stack.abs_path": true,
+	"path":           true,
+	"stack.module":   true,
+	"module":         true,
+	"stack.function": true,
+	"function":       true,
+	"stack.package":  true,
+	"package":        true,
+	"family":         true,
+	"app":            true,
+	"sdk":            true,
+	"release":        true,
+}
+
+// stackTraceMatchers are the matchers of stack trace rules.
+var stackTraceMatchers = map[string]bool{
+	"stack.abs_path": true,
+	"path":           true,
+	"stack.module":   true,
+	"module":         true,
+	"stack.function": true,
+	"function":       true,
+	"stack.package":  true,
+	"package":        true,
+	"family":         true,
+	"app":            true,
+	"error.type":     true,
+	"type":           true,
+	"error.value":    true,
+	"value":          true,
+	"mechanism":      true,
+	"category":       true,
+}
+
+var (
+	fingerprintingAttribute = regexp.MustCompile(`^title=".*"$`)
+	stackTraceAction        = regexp.MustCompile(`^[\^v]?[+-](app|group)$`)
+	stackTraceVariable      = regexp.MustCompile(`^(max-frames|min-frames|invert-stacktrace|category)=\S+$`)
+)
+
+func resourceSentryProjectGrouping() *schema.Resource {
+	return &schema.Resource{
+		Description: "Sentry Project Grouping resource. It manages the fingerprint rules, stack trace rules and " +
+			"grouping configuration of a project, and clears the rules when deleted.",
+
+		CreateContext: resourceSentryProjectGroupingUpdate,
+		ReadContext:   resourceSentryProjectGroupingRead,
+		UpdateContext: resourceSentryProjectGroupingUpdate,
+		DeleteContext: resourceSentryProjectGroupingDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importOrganizationScopedID,
+		},
+		CustomizeDiff: customizeDiffOrganization,
+
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Description: "The slug of the organization the project belongs to. Defaults to the provider `organization`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"project": {
+				Description: "The slug of the project to manage the grouping of.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"fingerprinting_rules": {
+				Description: "The fingerprint rules, one per line, such as `error.type:DatabaseUnavailable -> system-down`. " +
+					"Each rule is a list of matchers followed by `->` and the fingerprint, optionally with a " +
+					"`title=\"...\"`. Lines starting with `#` are comments. Whitespace is normalized.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "",
+				ValidateDiagFunc: validateRules("Invalid fingerprint rule", validateFingerprintingRule),
+				DiffSuppressFunc: suppressEquivalentRules,
+			},
+			"stack_trace_rules": {
+				Description: "The stack trace rules, one per line, such as `stack.module:com.example.* +app`. Each rule " +
+					"is a list of matchers followed by actions such as `-group` or `^+app`, or variables such as " +
+					"`max-frames=5`. Lines starting with `#` are comments. Whitespace is normalized.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "",
+				ValidateDiagFunc: validateRules("Invalid stack trace rule", validateStackTraceRule),
+				DiffSuppressFunc: suppressEquivalentRules,
+			},
+			"grouping_config": {
+				Description: "The version of the grouping algorithm, such as `newstyle:2023-01-11`. Defaults to the " +
+					"version of the project, which is kept when the resource is deleted.",
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSentryProjectGroupingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderData).ClientWithContext(ctx)
+
+	org, project, err := splitTwoPartID(d.Id(), "organization-slug", "project-slug")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Debug(ctx, "Reading project grouping", map[string]interface{}{
+		"org":     org,
+		"project": project,
+	})
+	grouping := new(projectGrouping)
+	err = requestProjectGrouping(ctx, client, http.MethodGet, org, project, nil, grouping)
+	if found, diags := checkClientGet(ctx, err, d); !found {
+		return diags
+	}
+
+	retErr := multierror.Append(
+		d.Set("organization", org),
+		d.Set("project", project),
+		d.Set("fingerprinting_rules", sentry.StringValue(grouping.FingerprintingRules)),
+		d.Set("stack_trace_rules", sentry.StringValue(grouping.GroupingEnhancements)),
+		d.Set("grouping_config", sentry.StringValue(grouping.GroupingConfig)),
+	)
+	return diag.FromErr(retErr.ErrorOrNil())
+}
+
+func resourceSentryProjectGroupingUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderData).ClientWithContext(ctx)
+
+	org := d.Get("organization").(string)
+	project := d.Get("project").(string)
+	params := &projectGrouping{
+		FingerprintingRules:  sentry.String(normalizeRules(d.Get("fingerprinting_rules").(string))),
+		GroupingEnhancements: sentry.String(normalizeRules(d.Get("stack_trace_rules").(string))),
+	}
+	if v, ok := d.GetOk("grouping_config"); ok {
+		params.GroupingConfig = sentry.String(v.(string))
+	}
+
+	tflog.Debug(ctx, "Updating project grouping", map[string]interface{}{
+		"org":     org,
+		"project": project,
+	})
+	if err := requestProjectGrouping(ctx, client, http.MethodPut, org, project, params, nil); err != nil {
+		return diagFromAPIErr(err, resourceSentryProjectGrouping(), map[string]string{
+			"fingerprintingRules":  "fingerprinting_rules",
+			"groupingEnhancements": "stack_trace_rules",
+			"groupingConfig":       "grouping_config",
+		})
+	}
+
+	d.SetId(buildTwoPartID(org, project))
+	return resourceSentryProjectGroupingRead(ctx, d, meta)
+}
+
+func resourceSentryProjectGroupingDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderData).ClientWithContext(ctx)
+
+	org, project, err := splitTwoPartID(d.Id(), "organization-slug", "project-slug")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The grouping config cannot be reset, as its default depends on when the
+	// project was created, so only the rules are cleared.
+	tflog.Debug(ctx, "Clearing project grouping rules", map[string]interface{}{
+		"org":     org,
+		"project": project,
+	})
+	err = requestProjectGrouping(ctx, client, http.MethodPut, org, project, &projectGrouping{
+		FingerprintingRules:  sentry.String(""),
+		GroupingEnhancements: sentry.String(""),
+	}, nil)
+	return checkClientDelete(ctx, err, d)
+}
+
+// projectGrouping holds the grouping settings of a project. go-sentry only
+// supports the stack trace rules.
+type projectGrouping struct {
+	FingerprintingRules  *string `json:"fingerprintingRules,omitempty"`
+	GroupingEnhancements *string `json:"groupingEnhancements,omitempty"`
+	GroupingConfig       *string `json:"groupingConfig,omitempty"`
+}
+
+func requestProjectGrouping(ctx context.Context, client *sentry.Client, method, org, project string, body, v interface{}) error {
+	req, err := client.NewRequest(method, fmt.Sprintf("0/projects/%v/%v/", org, project), body)
+	if err != nil {
+		return err
+	}
+	_, err = client.Do(ctx, req, v)
+	return err
+}
+
+// validateFingerprintingRule checks the syntax of a fingerprint rule, such
+// as `error.type:DatabaseUnavailable -> system-down title="Database down"`.
+func validateFingerprintingRule(line string) error {
+	fields := ruleFields(line)
+	if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+		return nil
+	}
+
+	arrow := indexUnquoted(line, "->")
+	if arrow < 0 {
+		return errors.New("missing -> between the matchers and the fingerprint")
+	}
+	matchers := ruleFields(line[:arrow])
+	if len(matchers) == 0 {
+		return errors.New("no matchers before ->")
+	}
+	for _, matcher := range matchers {
+		if err := validateGroupingMatcher(matcher, fingerprintingMatchers, true); err != nil {
+			return err
+		}
+	}
+
+	fingerprint := ruleFields(line[arrow+2:])
+	// Attributes such as the title of the issue follow the fingerprint.
+	for len(fingerprint) > 0 && strings.Contains(fingerprint[len(fingerprint)-1], "=") {
+		attribute := fingerprint[len(fingerprint)-1]
+		if !fingerprintingAttribute.MatchString(attribute) {
+			return fmt.Errorf("%q is not a valid attribute, expected title=\"...\"", attribute)
+		}
+		fingerprint = fingerprint[:len(fingerprint)-1]
+	}
+	value := strings.Join(fingerprint, " ")
+	if value == "" {
+		return errors.New("no fingerprint after ->")
+	}
+	if strings.Count(value, "{{") != strings.Count(value, "}}") {
+		return fmt.Errorf("%q has an unclosed {{ variable }}", value)
+	}
+	for _, part := range strings.Split(value, ",") {
+		if strings.TrimSpace(part) == "" {
+			return fmt.Errorf("%q has an empty fingerprint value", value)
+		}
+	}
+	return nil
+}
+
+// validateStackTraceRule checks the syntax of a stack trace rule, such as
+// `stack.function:panic_handler ^-group max-frames=5`.
+func validateStackTraceRule(line string) error {
+	fields := ruleFields(line)
+	if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+		return nil
+	}
+
+	var matchers, actions int
+	for _, field := range fields {
+		switch {
+		case stackTraceAction.MatchString(field) || stackTraceVariable.MatchString(field):
+			actions++
+		case field == "|" || field == "[" || field == "]":
+			// Caller and callee matchers are wrapped in brackets.
+		case actions > 0 || !strings.Contains(field, ":"):
+			return fmt.Errorf("%q is not a valid action, expected [^v][+-]app, [^v][+-]group or a variable such as max-frames=5", field)
+		default:
+			matcher := strings.TrimSuffix(strings.TrimPrefix(field, "["), "]")
+			if err := validateGroupingMatcher(matcher, stackTraceMatchers, false); err != nil {
+				return err
+			}
+			matchers++
+		}
+	}
+	if matchers == 0 {
+		return errors.New("no matchers before the actions")
+	}
+	if actions == 0 {
+		return errors.New("no actions after the matchers")
+	}
+	return nil
+}
+
+// validateGroupingMatcher checks a `key:value` matcher, optionally negated
+// with `!`.
+func validateGroupingMatcher(matcher string, known map[string]bool, tags bool) error {
+	key, value, ok := strings.Cut(strings.TrimPrefix(matcher, "!"), ":")
+	if !ok {
+		return fmt.Errorf("%q is not a valid matcher, expected key:value", matcher)
+	}
+	if !known[key] && !(tags && strings.HasPrefix(key, "tags.") && len(key) > len("tags.")) {
+		return fmt.Errorf("%q is not a known matcher", key)
+	}
+	if value == "" || value == `""` {
+		return fmt.Errorf("%q has no value", matcher)
+	}
+	return nil
+}
+
+// indexUnquoted returns the index of the first instance of substr outside
+// double quotes in s, or -1.
+func indexUnquoted(s, substr string) int {
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' {
+			quoted = !quoted
+			continue
+		}
+		if !quoted && strings.HasPrefix(s[i:], substr) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,130 @@
+package sentry
+
+import (
+	"testing"
+)
+
+func TestUnitSentryProjectGrouping_basic(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+	srv.AddProject(testUnitOrganization, "team", "tf-project")
+	r := newTestUnitResource(t, p, "sentry_project_grouping").withConfig(map[string]interface{}{
+		"organization":      testUnitOrganization,
+		"project":           "tf-project",
+		"stack_trace_rules": "stack.module:com.example.* +app\nstack.function:panic_handler ^-group",
+	})
+
+	r.apply(map[string]interface{}{"fingerprinting_rules": "# Database\n\nerror.type:DatabaseUnavailable   -> system-down\n"})
+	r.checkAttrs(map[string]string{
+		"id":                   buildTwoPartID(testUnitOrganization, "tf-project"),
+		"fingerprinting_rules": "# Database\nerror.type:DatabaseUnavailable -> system-down",
+		"stack_trace_rules":    "stack.module:com.example.* +app\nstack.function:panic_handler ^-group",
+		"grouping_config":      "newstyle:2023-01-11",
+	})
+
+	// Comments, blank lines and whitespace are normalized.
+	r.expectNoChanges(map[string]interface{}{"fingerprinting_rules": "  # Database\nerror.type:DatabaseUnavailable -> system-down\n\n"})
+
+	// Changes made outside of Terraform are detected.
+	srv.SetProjectFingerprintingRules(testUnitOrganization, "tf-project", "message:\"*timeout*\" -> timeout")
+	r.refresh()
+	r.checkAttrs(map[string]string{
+		"fingerprinting_rules": "message:\"*timeout*\" -> timeout",
+	})
+
+	r.apply(map[string]interface{}{
+		"fingerprinting_rules": `logger:"api.*" tags.server_name:web-* -> {{ default }}, web title="API error"`,
+		"grouping_config":      "newstyle:2019-10-29",
+	})
+	r.checkAttrs(map[string]string{
+		"fingerprinting_rules": `logger:"api.*" tags.server_name:web-* -> {{ default }}, web title="API error"`,
+		"grouping_config":      "newstyle:2019-10-29",
+	})
+
+	r.checkImport(buildTwoPartID(testUnitOrganization, "tf-project"))
+
+	// Deleting the resource clears the rules and keeps the grouping config.
+	prev := r.destroy()
+	refreshed := r.importState(prev.ID)
+	for k, want := range map[string]string{
+		"fingerprinting_rules": "",
+		"stack_trace_rules":    "",
+		"grouping_config":      "newstyle:2019-10-29",
+	} {
+		if got := refreshed.Attributes[k]; got != want {
+			t.Errorf("attribute %s after destroy: got %q, want %q", k, got, want)
+		}
+	}
+}
+
+func TestUnitSentryProjectGrouping_invalidRules(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+	srv.AddProject(testUnitOrganization, "team", "tf-project")
+	r := newTestUnitResource(t, p, "sentry_project_grouping")
+
+	r.expectPlanError(map[string]interface{}{
+		"project":              "tf-project",
+		"fingerprinting_rules": "error.type:Timeout -> timeout\nerror.type:Unavailable system-down",
+	}, "Line 2: missing -> between the matchers and the fingerprint")
+	r.expectPlanError(map[string]interface{}{
+		"project":           "tf-project",
+		"stack_trace_rules": "stack.function:foo +ap",
+	}, `Line 1: "+ap" is not a valid action`)
+}
+
+func TestValidateFingerprintingRule(t *testing.T) {
+	for _, tc := range []struct {
+		line string
+		want string
+	}{
+		{line: ""},
+		{line: "# comment"},
+		{line: "error.type:DatabaseUnavailable -> system-down"},
+		{line: `!message:"foo -> bar" family:native -> {{ default }}, {{ error.value }}`},
+		{line: `tags.server_name:web-1 -> web title="Web {{ error.type }}"`},
+		{line: "-> system-down", want: "no matchers before ->"},
+		{line: "error.type:Foo ->", want: "no fingerprint after ->"},
+		{line: "error.type:Foo", want: "missing -> between the matchers and the fingerprint"},
+		{line: "file:foo.py -> foo", want: `"file" is not a known matcher`},
+		{line: "error.type: -> foo", want: `"error.type:" has no value`},
+		{line: "error.type:Foo -> {{ default", want: `"{{ default" has an unclosed {{ variable }}`},
+		{line: "error.type:Foo -> a,,b", want: `"a,,b" has an empty fingerprint value`},
+		{line: "error.type:Foo -> foo label=bar", want: `"label=bar" is not a valid attribute, expected title="..."`},
+	} {
+		err := validateFingerprintingRule(tc.line)
+		got := ""
+		if err != nil {
+			got = err.Error()
+		}
+		if got != tc.want {
+			t.Errorf("validateFingerprintingRule(%q): got %q, want %q", tc.line, got, tc.want)
+		}
+	}
+}
+
+func TestValidateStackTraceRule(t *testing.T) {
+	for _, tc := range []struct {
+		line string
+		want string
+	}{
+		{line: ""},
+		{line: "# comment"},
+		{line: "stack.module:com.example.* +app"},
+		{line: "family:native !stack.function:std::* -app v+group max-frames=5"},
+		{line: "[ stack.function:foo ] | stack.function:bar -group"},
+		{line: "stack.function:foo", want: "no actions after the matchers"},
+		{line: "+app", want: "no matchers before the actions"},
+		{line: "stack.function:foo -app stack.module:bar", want: `"stack.module:bar" is not a valid action, expected [^v][+-]app, [^v][+-]group or a variable such as max-frames=5`},
+		{line: "message:foo -group", want: `"message" is not a known matcher`},
+	} {
+		err := validateStackTraceRule(tc.line)
+		got := ""
+		if err != nil {
+			got = err.Error()
+		}
+		if got != tc.want {
+			t.Errorf("validateStackTraceRule(%q): got %q, want %q", tc.line, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,356 @@
+package sentry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+// projectInboundFilters maps the attributes of the inbound filters turned on
+// by an empty block to their IDs.
+var projectInboundFilters = map[string]string{
+	"browser_extensions": "browser-extensions",
+	"localhost":          "localhost",
+	"web_crawlers":       "web-crawlers",
+}
+
+// projectInboundFilterOptions maps the attributes of the inbound filters
+// stored as newline-separated project options to the options and to the
+// attribute of their block listing the values.
+var projectInboundFilterOptions = map[string]struct{ option, values string }{
+	"releases":        {option: "filters:releases", values: "patterns"},
+	"error_messages":  {option: "filters:error_messages", values: "patterns"},
+	"blacklisted_ips": {option: "filters:blacklisted_ips", values: "addresses"},
+}
+
+func resourceSentryProjectInboundFilters() *schema.Resource {
+	return &schema.Resource{
+		Description: "Sentry Project Inbound Data Filters resource. It manages all the inbound data filters of a " +
+			"project, and resets them when deleted. Each filter is turned on by its block.",
+
+		CreateContext: resourceSentryProjectInboundFiltersUpdate,
+		ReadContext:   resourceSentryProjectInboundFiltersRead,
+		UpdateContext: resourceSentryProjectInboundFiltersUpdate,
+		DeleteContext: resourceSentryProjectInboundFiltersDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importOrganizationScopedID,
+		},
+		CustomizeDiff: customizeDiffOrganization,
+
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Description: "The slug of the organization the project belongs to. Defaults to the provider `organization`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"project": {
+				Description: "The slug of the project to filter inbound data of.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"browser_extensions": {
+				Description: "Filter out errors known to be caused by browser extensions.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{},
+				},
+			},
+			"localhost": {
+				Description: "Filter out events coming from localhost.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{},
+				},
+			},
+			"web_crawlers": {
+				Description: "Filter out known web crawlers.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{},
+				},
+			},
+			"legacy_browsers": {
+				Description: "Filter out events of legacy browsers.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"browsers": {
+							Description:  "The legacy browsers to filter out events of, such as `ie_pre_9` or `safari_pre_6`.",
+							Type:         schema.TypeSet,
+							Optional:     true,
+							ExactlyOneOf: []string{"legacy_browsers.0.browsers", "legacy_browsers.0.all"},
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"all": {
+							Description:  "Filter out events of all the legacy browsers.",
+							Type:         schema.TypeBool,
+							Optional:     true,
+							ExactlyOneOf: []string{"legacy_browsers.0.browsers", "legacy_browsers.0.all"},
+						},
+					},
+				},
+			},
+			"releases": {
+				Description: "Filter out events of some releases.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"patterns": {
+							Description: "The glob patterns matching the releases.",
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"error_messages": {
+				Description: "Filter out errors by their message.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"patterns": {
+							Description: "The glob patterns matching the messages.",
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"blacklisted_ips": {
+				Description: "Filter out events coming from some IP addresses.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"addresses": {
+							Description: "The IP addresses or CIDR ranges.",
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceSentryProjectInboundFiltersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderData).ClientWithContext(ctx)
+
+	org, project, err := splitTwoPartID(d.Id(), "organization-slug", "project-slug")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Debug(ctx, "Reading project inbound filters", map[string]interface{}{
+		"org":     org,
+		"project": project,
+	})
+	filters, _, err := client.ProjectFilter.Get(ctx, org, project)
+	if found, diags := checkClientGet(ctx, err, d); !found {
+		return diags
+	}
+	proj, _, err := client.Projects.Get(ctx, org, project)
+	if found, diags := checkClientGet(ctx, err, d); !found {
+		return diags
+	}
+
+	retErr := multierror.Append(
+		d.Set("organization", org),
+		d.Set("project", project),
+	)
+	for _, filter := range filters {
+		if filter.ID == "legacy-browsers" {
+			blocks, err := flattenProjectLegacyBrowsersFilter(filter.Active)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			retErr = multierror.Append(retErr, d.Set("legacy_browsers", blocks))
+			continue
+		}
+		for attr, id := range projectInboundFilters {
+			if filter.ID != id {
+				continue
+			}
+			var blocks []interface{}
+			if string(filter.Active) == "true" {
+				blocks = []interface{}{map[string]interface{}{}}
+			}
+			retErr = multierror.Append(retErr, d.Set(attr, blocks))
+		}
+	}
+	for attr, filter := range projectInboundFilterOptions {
+		value, _ := proj.Options[filter.option].(string)
+		var blocks []interface{}
+		if values := splitLines(value); len(values) > 0 {
+			blocks = []interface{}{map[string]interface{}{filter.values: values}}
+		}
+		retErr = multierror.Append(retErr, d.Set(attr, blocks))
+	}
+
+	return diag.FromErr(retErr.ErrorOrNil())
+}
+
+// flattenProjectLegacyBrowsersFilter returns the legacy_browsers block of the
+// state of the filter: false, true for all the legacy browsers, or a list of
+// them.
+func flattenProjectLegacyBrowsersFilter(active json.RawMessage) ([]interface{}, error) {
+	switch string(active) {
+	case "false":
+		return nil, nil
+	case "true":
+		return []interface{}{map[string]interface{}{"all": true}}, nil
+	}
+
+	var browsers []string
+	if err := json.Unmarshal(active, &browsers); err != nil {
+		return nil, fmt.Errorf("invalid legacy-browsers filter: %w", err)
+	}
+	if len(browsers) == 0 {
+		return nil, nil
+	}
+	return []interface{}{map[string]interface{}{"browsers": flattenStringSet(browsers)}}, nil
+}
+
+func resourceSentryProjectInboundFiltersUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderData).ClientWithContext(ctx)
+
+	org := d.Get("organization").(string)
+	project := d.Get("project").(string)
+
+	tflog.Debug(ctx, "Updating project inbound filters", map[string]interface{}{
+		"org":     org,
+		"project": project,
+	})
+
+	// A new resource takes over all the filters of the project.
+	for attr, id := range projectInboundFilters {
+		if !d.IsNewResource() && !d.HasChange(attr) {
+			continue
+		}
+		active := len(d.Get(attr).([]interface{})) > 0
+		if _, err := updateProjectFilter(ctx, client, org, project, id, active); err != nil {
+			return diagFromAPIErr(err, nil, nil)
+		}
+	}
+	if d.IsNewResource() || d.HasChange("legacy_browsers") {
+		var err error
+		if all := d.Get("legacy_browsers.0.all").(bool); all {
+			_, err = updateProjectFilter(ctx, client, org, project, "legacy-browsers", true)
+		} else {
+			browsers := expandStringList(d.Get("legacy_browsers.0.browsers").(*schema.Set).List())
+			_, err = client.ProjectFilter.UpdateLegacyBrowser(ctx, org, project, browsers)
+		}
+		if err != nil {
+			return diagFromAPIErr(err, resourceSentryProjectInboundFilters(), map[string]string{"subfilters": "legacy_browsers"})
+		}
+	}
+
+	options := make(map[string]interface{})
+	for attr, filter := range projectInboundFilterOptions {
+		if !d.IsNewResource() && !d.HasChange(attr) {
+			continue
+		}
+		var values []string
+		if v, ok := d.GetOk(attr + ".0." + filter.values); ok {
+			values = expandStringList(v.([]interface{}))
+		}
+		options[filter.option] = strings.Join(values, "\n")
+	}
+	if len(options) > 0 {
+		if _, _, err := client.Projects.Update(ctx, org, project, &sentry.UpdateProjectParams{Options: options}); err != nil {
+			return diagFromAPIErr(err, nil, nil)
+		}
+	}
+
+	d.SetId(buildTwoPartID(org, project))
+	return resourceSentryProjectInboundFiltersRead(ctx, d, meta)
+}
+
+func resourceSentryProjectInboundFiltersDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderData).ClientWithContext(ctx)
+
+	org, project, err := splitTwoPartID(d.Id(), "organization-slug", "project-slug")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Debug(ctx, "Resetting project inbound filters", map[string]interface{}{
+		"org":     org,
+		"project": project,
+	})
+	for _, id := range projectInboundFilters {
+		if _, err := updateProjectFilter(ctx, client, org, project, id, false); err != nil {
+			return checkClientDelete(ctx, err, d)
+		}
+	}
+	if _, err := client.ProjectFilter.UpdateLegacyBrowser(ctx, org, project, []string{}); err != nil {
+		return checkClientDelete(ctx, err, d)
+	}
+	options := make(map[string]interface{}, len(projectInboundFilterOptions))
+	for _, filter := range projectInboundFilterOptions {
+		options[filter.option] = ""
+	}
+	_, _, err = client.Projects.Update(ctx, org, project, &sentry.UpdateProjectParams{Options: options})
+	return checkClientDelete(ctx, err, d)
+}
+
+// updateProjectFilter enables or disables a boolean inbound filter. go-sentry
+// only covers the browser extensions filter.
+func updateProjectFilter(ctx context.Context, client *sentry.Client, org, project, id string, active bool) (*sentry.Response, error) {
+	url := fmt.Sprintf("0/projects/%v/%v/filters/%v/", org, project, id)
+	req, err := client.NewRequest(http.MethodPut, url, &sentry.BrowserExtensionParams{Active: active})
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(ctx, req, nil)
+}
+
+// splitLines splits a newline-separated project option, skipping empty lines.
+func splitLines(s string) []string {
+	lines := []string{}
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,138 @@
+package sentry
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnitSentryProjectInboundFilters_basic(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+	srv.AddProject(testUnitOrganization, "team", "tf-project")
+	r := newTestUnitResource(t, p, "sentry_project_inbound_filters").withConfig(map[string]interface{}{
+		"organization":       testUnitOrganization,
+		"project":            "tf-project",
+		"browser_extensions": []interface{}{map[string]interface{}{}},
+		"web_crawlers":       []interface{}{map[string]interface{}{}},
+		"legacy_browsers": []interface{}{map[string]interface{}{
+			"browsers": []interface{}{"ie_pre_9", "safari_pre_6"},
+		}},
+		"releases": []interface{}{map[string]interface{}{
+			"patterns": []interface{}{"1.*", "beta-*"},
+		}},
+		"error_messages": []interface{}{map[string]interface{}{
+			"patterns": []interface{}{"*ResizeObserver*"},
+		}},
+		"blacklisted_ips": []interface{}{map[string]interface{}{
+			"addresses": []interface{}{"10.0.0.0/8"},
+		}},
+	})
+	r.step(nil, map[string]string{
+		"id":                            buildTwoPartID(testUnitOrganization, "tf-project"),
+		"browser_extensions.#":          "1",
+		"localhost.#":                   "0",
+		"web_crawlers.#":                "1",
+		"legacy_browsers.0.browsers.#":  "2",
+		"legacy_browsers.0.all":         "false",
+		"releases.0.patterns.#":         "2",
+		"releases.0.patterns.0":         "1.*",
+		"releases.0.patterns.1":         "beta-*",
+		"error_messages.0.patterns.0":   "*ResizeObserver*",
+		"blacklisted_ips.0.addresses.0": "10.0.0.0/8",
+	})
+	if got, _ := srv.ProjectOption(testUnitOrganization, "tf-project", "filters:releases"); got != "1.*\nbeta-*" {
+		t.Errorf("option filters:releases: got %#v", got)
+	}
+
+	// Changes made outside of Terraform are detected.
+	srv.SetProjectFilter(testUnitOrganization, "tf-project", "localhost", true)
+	srv.SetProjectFilter(testUnitOrganization, "tf-project", "legacy-browsers", []string{"ie_pre_9"})
+	srv.SetProjectOption(testUnitOrganization, "tf-project", "filters:blacklisted_ips", "10.0.0.0/8\n192.168.0.1")
+	srv.SetProjectOption(testUnitOrganization, "tf-project", "filters:error_messages", "")
+	r.refresh()
+	r.checkAttrs(map[string]string{
+		"localhost.#":                   "1",
+		"legacy_browsers.0.browsers.#":  "1",
+		"blacklisted_ips.0.addresses.#": "2",
+		"blacklisted_ips.0.addresses.1": "192.168.0.1",
+		"error_messages.#":              "0",
+	})
+	r.apply(nil)
+	r.checkAttrs(map[string]string{
+		"localhost.#":                   "0",
+		"legacy_browsers.0.browsers.#":  "2",
+		"blacklisted_ips.0.addresses.#": "1",
+		"error_messages.#":              "1",
+	})
+
+	// Removing a block turns its filter off.
+	removed := map[string]interface{}{"web_crawlers": nil, "releases": nil}
+	r.step(removed, map[string]string{
+		"web_crawlers.#": "0",
+		"releases.#":     "0",
+	})
+	if got := srv.ProjectFilter(testUnitOrganization, "tf-project", "web-crawlers"); got != false {
+		t.Errorf("filter web-crawlers: got %#v, want false", got)
+	}
+	if got, _ := srv.ProjectOption(testUnitOrganization, "tf-project", "filters:releases"); got != "" {
+		t.Errorf("option filters:releases: got %#v, want empty", got)
+	}
+
+	r.checkImport(buildTwoPartID(testUnitOrganization, "tf-project"))
+
+	// Deleting the resource resets every filter.
+	r.destroy()
+	for _, id := range []string{"browser-extensions", "localhost", "legacy-browsers", "web-crawlers"} {
+		if got := srv.ProjectFilter(testUnitOrganization, "tf-project", id); !reflect.DeepEqual(got, false) {
+			t.Errorf("filter %s: got %#v, want false", id, got)
+		}
+	}
+	if got, _ := srv.ProjectOption(testUnitOrganization, "tf-project", "filters:blacklisted_ips"); got != "" {
+		t.Errorf("option filters:blacklisted_ips: got %#v, want empty", got)
+	}
+}
+
+func TestUnitSentryProjectInboundFilters_allLegacyBrowsers(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+	srv.AddProject(testUnitOrganization, "team", "tf-project")
+	r := newTestUnitResource(t, p, "sentry_project_inbound_filters").withConfig(map[string]interface{}{
+		"organization": testUnitOrganization,
+		"project":      "tf-project",
+	})
+
+	// Sentry reports the filter as true when it applies to all the legacy
+	// browsers.
+	srv.SetProjectFilter(testUnitOrganization, "tf-project", "legacy-browsers", true)
+	state := r.importState(buildTwoPartID(testUnitOrganization, "tf-project"))
+	for k, want := range map[string]string{
+		"legacy_browsers.#":            "1",
+		"legacy_browsers.0.all":        "true",
+		"legacy_browsers.0.browsers.#": "0",
+	} {
+		if got := state.Attributes[k]; got != want {
+			t.Errorf("attribute %s: got %q, want %q", k, got, want)
+		}
+	}
+
+	r.apply(map[string]interface{}{
+		"legacy_browsers": []interface{}{map[string]interface{}{
+			"browsers": []interface{}{"ie_pre_9"},
+		}},
+	})
+	r.checkAttrs(map[string]string{"legacy_browsers.0.browsers.#": "1"})
+
+	r.step(map[string]interface{}{
+		"legacy_browsers": []interface{}{map[string]interface{}{"all": true}},
+	}, map[string]string{
+		"legacy_browsers.0.all":        "true",
+		"legacy_browsers.0.browsers.#": "0",
+	})
+	if got := srv.ProjectFilter(testUnitOrganization, "tf-project", "legacy-browsers"); got != true {
+		t.Errorf("filter legacy-browsers: got %#v, want true", got)
+	}
+
+	r.expectPlanError(map[string]interface{}{
+		"legacy_browsers": []interface{}{map[string]interface{}{}},
+	}, "one of `legacy_browsers.0.all,legacy_browsers.0.browsers` must be specified")
+}
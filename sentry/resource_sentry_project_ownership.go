@@ -0,0 +1,253 @@
+package sentry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+// ownershipAutoAssignments maps the auto_assignment values to the modes of
+// the Sentry API.
+var ownershipAutoAssignments = map[string]string{
+	"issue_owner":     "Auto Assign to Issue Owner",
+	"suspect_commits": "Auto Assign to Suspect Commits",
+	"off":             "Turn off Auto-Assignment",
+}
+
+func resourceSentryProjectOwnership() *schema.Resource {
+	return &schema.Resource{
+		Description: "Sentry Project Ownership resource. It manages the issue owners rules and auto-assignment " +
+			"settings of a project, and resets them when deleted.",
+
+		CreateContext: resourceSentryProjectOwnershipUpdate,
+		ReadContext:   resourceSentryProjectOwnershipRead,
+		UpdateContext: resourceSentryProjectOwnershipUpdate,
+		DeleteContext: resourceSentryProjectOwnershipDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importOrganizationScopedID,
+		},
+		CustomizeDiff: customizeDiffOrganization,
+
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Description: "The slug of the organization the project belongs to. Defaults to the provider `organization`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"project": {
+				Description: "The slug of the project to manage the ownership of.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"raw": {
+				Description: "The ownership rules, one per line, such as `path:src/api/* #backend user@example.com`. " +
+					"Each rule is a `path:`, `url:`, `module:`, `codeowners:` or `tags.<key>:` matcher followed " +
+					"by `#team` or email owners. Lines starting with `#` are comments. Whitespace is normalized.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "",
+				ValidateDiagFunc: validateRules("Invalid ownership rule", validateOwnershipRule),
+				DiffSuppressFunc: suppressEquivalentRules,
+			},
+			"fallthrough": {
+				Description: "Whether issues that match no rule are assigned to everyone in the project.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"auto_assignment": {
+				Description: "How issues are automatically assigned: to the owners matching the rules (`issue_owner`), " +
+					"to the authors of suspect commits (`suspect_commits`), or not at all (`off`).",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "issue_owner",
+				ValidateFunc: validation.StringInSlice([]string{"issue_owner", "suspect_commits", "off"}, false),
+			},
+			"codeowners_auto_sync": {
+				Description: "Whether CODEOWNERS files of linked repositories are synced automatically.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+		},
+	}
+}
+
+func resourceSentryProjectOwnershipRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderData).ClientWithContext(ctx)
+
+	org, project, err := splitTwoPartID(d.Id(), "organization-slug", "project-slug")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Debug(ctx, "Reading project ownership", map[string]interface{}{
+		"org":     org,
+		"project": project,
+	})
+	ownership, err := getProjectOwnership(ctx, client, org, project)
+	if found, diags := checkClientGet(ctx, err, d); !found {
+		return diags
+	}
+
+	retErr := multierror.Append(
+		d.Set("organization", org),
+		d.Set("project", project),
+		d.Set("raw", ownership.Raw),
+		d.Set("fallthrough", ownership.FallThrough),
+		d.Set("auto_assignment", flattenOwnershipAutoAssignment(ownership.AutoAssignment)),
+	)
+	if ownership.CodeownersAutoSync != nil {
+		retErr = multierror.Append(retErr, d.Set("codeowners_auto_sync", *ownership.CodeownersAutoSync))
+	}
+
+	return diag.FromErr(retErr.ErrorOrNil())
+}
+
+func resourceSentryProjectOwnershipUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderData).ClientWithContext(ctx)
+
+	org := d.Get("organization").(string)
+	project := d.Get("project").(string)
+	params := &projectOwnershipParams{
+		Raw:                sentry.String(normalizeRules(d.Get("raw").(string))),
+		FallThrough:        sentry.Bool(d.Get("fallthrough").(bool)),
+		AutoAssignment:     sentry.String(ownershipAutoAssignments[d.Get("auto_assignment").(string)]),
+		CodeownersAutoSync: sentry.Bool(d.Get("codeowners_auto_sync").(bool)),
+	}
+
+	tflog.Debug(ctx, "Updating project ownership", map[string]interface{}{
+		"org":     org,
+		"project": project,
+	})
+	if err := updateProjectOwnership(ctx, client, org, project, params); err != nil {
+		return diagFromAPIErr(err, resourceSentryProjectOwnership(), map[string]string{
+			"autoAssignment":     "auto_assignment",
+			"codeownersAutoSync": "codeowners_auto_sync",
+		})
+	}
+
+	d.SetId(buildTwoPartID(org, project))
+	return resourceSentryProjectOwnershipRead(ctx, d, meta)
+}
+
+func resourceSentryProjectOwnershipDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderData).ClientWithContext(ctx)
+
+	org, project, err := splitTwoPartID(d.Id(), "organization-slug", "project-slug")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Ownership cannot be deleted, so it is reset to the defaults of Sentry.
+	tflog.Debug(ctx, "Resetting project ownership", map[string]interface{}{
+		"org":     org,
+		"project": project,
+	})
+	err = updateProjectOwnership(ctx, client, org, project, &projectOwnershipParams{
+		Raw:                sentry.String(""),
+		FallThrough:        sentry.Bool(true),
+		AutoAssignment:     sentry.String(ownershipAutoAssignments["issue_owner"]),
+		CodeownersAutoSync: sentry.Bool(true),
+	})
+	return checkClientDelete(ctx, err, d)
+}
+
+// projectOwnership is the ownership of a project. go-sentry decodes
+// autoAssignment as a bool, which Sentry has since replaced with a mode.
+type projectOwnership struct {
+	Raw                string      `json:"raw"`
+	FallThrough        bool        `json:"fallthrough"`
+	AutoAssignment     interface{} `json:"autoAssignment"`
+	CodeownersAutoSync *bool       `json:"codeownersAutoSync"`
+}
+
+// projectOwnershipParams updates the ownership of a project. Unlike
+// sentry.UpdateProjectOwnershipParams, it can clear the rules.
+type projectOwnershipParams struct {
+	Raw                *string `json:"raw,omitempty"`
+	FallThrough        *bool   `json:"fallthrough,omitempty"`
+	AutoAssignment     *string `json:"autoAssignment,omitempty"`
+	CodeownersAutoSync *bool   `json:"codeownersAutoSync,omitempty"`
+}
+
+func getProjectOwnership(ctx context.Context, client *sentry.Client, org, project string) (*projectOwnership, error) {
+	url := fmt.Sprintf("0/projects/%v/%v/ownership/", org, project)
+	req, err := client.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	ownership := new(projectOwnership)
+	if _, err := client.Do(ctx, req, ownership); err != nil {
+		return nil, err
+	}
+	return ownership, nil
+}
+
+func updateProjectOwnership(ctx context.Context, client *sentry.Client, org, project string, params *projectOwnershipParams) error {
+	url := fmt.Sprintf("0/projects/%v/%v/ownership/", org, project)
+	req, err := client.NewRequest(http.MethodPut, url, params)
+	if err != nil {
+		return err
+	}
+	_, err = client.Do(ctx, req, nil)
+	return err
+}
+
+// flattenOwnershipAutoAssignment returns the auto_assignment value of a
+// mode, or of the bool older versions of Sentry return.
+func flattenOwnershipAutoAssignment(v interface{}) string {
+	switch v := v.(type) {
+	case bool:
+		if v {
+			return "issue_owner"
+		}
+		return "off"
+	case string:
+		for value, mode := range ownershipAutoAssignments {
+			if mode == v {
+				return value
+			}
+		}
+		return v
+	}
+	return "off"
+}
+
+var (
+	ownershipMatcherTag = regexp.MustCompile(`^(path|url|module|codeowners|tags\.[^\s:]+):(.+)$`)
+	ownershipTeam       = regexp.MustCompile(`^#[^\s#]+$`)
+	ownershipEmail      = regexp.MustCompile(`^[^\s@]+@[^\s@]+\.[^\s@]+$`)
+)
+
+// validateOwnershipRule checks the syntax of an ownership rule.
+func validateOwnershipRule(line string) error {
+	fields := ruleFields(line)
+	if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+		return nil
+	}
+	if !ownershipMatcherTag.MatchString(fields[0]) {
+		return fmt.Errorf("%q is not a valid matcher, expected path:, url:, module:, codeowners: or tags.<key>: followed by a pattern", fields[0])
+	}
+	if len(fields) == 1 {
+		return fmt.Errorf("%q has no owners", fields[0])
+	}
+	for _, owner := range fields[1:] {
+		if !ownershipTeam.MatchString(owner) && !ownershipEmail.MatchString(owner) {
+			return fmt.Errorf("%q is not a valid owner, expected #team-slug or an email address", owner)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package sentry
+
+import (
+	"testing"
+)
+
+func TestUnitSentryProjectOwnership_basic(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+	srv.AddProject(testUnitOrganization, "team", "tf-project")
+	r := newTestUnitResource(t, p, "sentry_project_ownership").withConfig(map[string]interface{}{
+		"organization":    testUnitOrganization,
+		"project":         "tf-project",
+		"fallthrough":     false,
+		"auto_assignment": "suspect_commits",
+	})
+
+	r.apply(map[string]interface{}{"raw": "# Backend\npath:src/api/*   #backend\n\nurl:*/checkout/* #payments user@example.com\n"})
+	r.checkAttrs(map[string]string{
+		"id":                   buildTwoPartID(testUnitOrganization, "tf-project"),
+		"raw":                  "# Backend\npath:src/api/* #backend\nurl:*/checkout/* #payments user@example.com",
+		"fallthrough":          "false",
+		"auto_assignment":      "suspect_commits",
+		"codeowners_auto_sync": "true",
+	})
+
+	// Whitespace differences are not changes.
+	r.expectNoChanges(map[string]interface{}{"raw": "# Backend\n  path:src/api/* #backend\nurl:*/checkout/*\t#payments   user@example.com"})
+
+	// Changes made outside of Terraform are detected.
+	srv.SetProjectOwnershipRules(testUnitOrganization, "tf-project", "path:src/* #frontend")
+	r.refresh()
+	r.checkAttrs(map[string]string{
+		"raw": "path:src/* #frontend",
+	})
+
+	r.apply(map[string]interface{}{"raw": "tags.browser:Chrome* #frontend"})
+	r.checkAttrs(map[string]string{
+		"raw": "tags.browser:Chrome* #frontend",
+	})
+
+	r.checkImport(buildTwoPartID(testUnitOrganization, "tf-project"))
+
+	// Deleting the resource resets the ownership.
+	prev := r.destroy()
+	refreshed := r.importState(prev.ID)
+	for k, want := range map[string]string{
+		"raw":             "",
+		"fallthrough":     "true",
+		"auto_assignment": "issue_owner",
+	} {
+		if got := refreshed.Attributes[k]; got != want {
+			t.Errorf("attribute %s after destroy: got %q, want %q", k, got, want)
+		}
+	}
+}
+
+func TestUnitSentryProjectOwnership_invalidRules(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+	srv.AddProject(testUnitOrganization, "team", "tf-project")
+	r := newTestUnitResource(t, p, "sentry_project_ownership")
+
+	r.expectPlanError(map[string]interface{}{
+		"project": "tf-project",
+		"raw":     "path:src/* #backend\nfile:src/* #backend",
+	}, `Line 2: "file:src/*" is not a valid matcher`)
+}
+
+func TestValidateOwnershipRule(t *testing.T) {
+	for _, tc := range []struct {
+		line string
+		want string
+	}{
+		{line: ""},
+		{line: "# Comment"},
+		{line: "path:src/* #backend"},
+		{line: `path:"My Documents/*" #backend`},
+		{line: "url:https://example.com/* user@example.com #frontend"},
+		{line: "module:com.example.* #backend"},
+		{line: "codeowners:src/* #backend"},
+		{line: "tags.sku_class:enterprise #enterprise"},
+		{line: "path:src/*", want: `"path:src/*" has no owners`},
+		{line: "tags.:x #backend", want: `"tags.:x" is not a valid matcher, expected path:, url:, module:, codeowners: or tags.<key>: followed by a pattern`},
+		{line: "path:src/* backend", want: `"backend" is not a valid owner, expected #team-slug or an email address`},
+		{line: "path:src/* @user", want: `"@user" is not a valid owner, expected #team-slug or an email address`},
+	} {
+		err := validateOwnershipRule(tc.line)
+		got := ""
+		if err != nil {
+			got = err.Error()
+		}
+		if got != tc.want {
+			t.Errorf("validateOwnershipRule(%q): got %q, want %q", tc.line, got, tc.want)
+		}
+	}
+}
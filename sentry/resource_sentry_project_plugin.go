@@ -7,7 +7,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/jianyuan/go-sentry/v2/sentry"
 )
 
 func resourceSentryPlugin() *schema.Resource {
@@ -21,12 +20,14 @@ func resourceSentryPlugin() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: importOrganizationProjectAndID,
 		},
+		CustomizeDiff: customizeDiffOrganization,
 
 		Schema: map[string]*schema.Schema{
 			"organization": {
-				Description: "The slug of the organization the project belongs to.",
+				Description: "The slug of the organization the project belongs to. Defaults to the provider `organization`.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 			},
 			"project": {
 				Description: "The slug of the project to create the plugin for.",
@@ -48,7 +49,7 @@ func resourceSentryPlugin() *schema.Resource {
 }
 
 func resourceSentryPluginCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	plugin := d.Get("plugin").(string)
 	org := d.Get("organization").(string)
@@ -61,7 +62,7 @@ func resourceSentryPluginCreate(ctx context.Context, d *schema.ResourceData, met
 	})
 	_, err := client.ProjectPlugins.Enable(ctx, org, project, plugin)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIErr(err, resourceSentryPlugin(), nil)
 	}
 	tflog.Debug(ctx, "Created Sentry plugin", map[string]interface{}{
 		"pluginName": plugin,
@@ -73,14 +74,14 @@ func resourceSentryPluginCreate(ctx context.Context, d *schema.ResourceData, met
 
 	params := d.Get("config").(map[string]interface{})
 	if _, _, err := client.ProjectPlugins.Update(ctx, org, project, plugin, params); err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIErr(err, resourceSentryPlugin(), nil)
 	}
 
 	return resourceSentryPluginRead(ctx, d, meta)
 }
 
 func resourceSentryPluginRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	id := d.Id()
 	org := d.Get("organization").(string)
@@ -91,9 +92,9 @@ func resourceSentryPluginRead(ctx context.Context, d *schema.ResourceData, meta
 		"org":      org,
 		"project":  project,
 	})
-	plugin, resp, err := client.ProjectPlugins.Get(ctx, org, project, id)
-	if found, err := checkClientGet(resp, err, d); !found {
-		return diag.FromErr(err)
+	plugin, _, err := client.ProjectPlugins.Get(ctx, org, project, id)
+	if found, diags := checkClientGet(ctx, err, d); !found {
+		return diags
 	}
 	tflog.Debug(ctx, "Read Sentry plugin", map[string]interface{}{
 		"pluginID": plugin.ID,
@@ -122,7 +123,7 @@ func resourceSentryPluginRead(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceSentryPluginUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	id := d.Id()
 	org := d.Get("organization").(string)
@@ -136,7 +137,7 @@ func resourceSentryPluginUpdate(ctx context.Context, d *schema.ResourceData, met
 	params := d.Get("config").(map[string]interface{})
 	plugin, _, err := client.ProjectPlugins.Update(ctx, org, project, id, params)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIErr(err, resourceSentryPlugin(), nil)
 	}
 	tflog.Debug(ctx, "Updated Sentry plugin", map[string]interface{}{
 		"pluginID": plugin.ID,
@@ -148,7 +149,7 @@ func resourceSentryPluginUpdate(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceSentryPluginDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	id := d.Id()
 	org := d.Get("organization").(string)
@@ -166,5 +167,5 @@ func resourceSentryPluginDelete(ctx context.Context, d *schema.ResourceData, met
 		"project":  project,
 	})
 
-	return diag.FromErr(err)
+	return checkClientDelete(ctx, err, d)
 }
@@ -0,0 +1,29 @@
+package sentry
+
+import (
+	"testing"
+)
+
+func TestUnitSentryPlugin_basic(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+	srv.AddProject(testUnitOrganization, "team", "project")
+	r := newTestUnitResource(t, p, "sentry_plugin").withConfig(map[string]interface{}{
+		"organization": testUnitOrganization,
+		"project":      "project",
+		"plugin":       "slack",
+	})
+
+	r.step(map[string]interface{}{"config": map[string]interface{}{"webhook": "https://hooks.example.com/a"}}, map[string]string{
+		"id":             "slack",
+		"config.%":       "1",
+		"config.webhook": "https://hooks.example.com/a",
+	})
+
+	r.apply(map[string]interface{}{"config": map[string]interface{}{"webhook": "https://hooks.example.com/b"}})
+	r.checkAttrs(map[string]string{
+		"config.webhook": "https://hooks.example.com/b",
+	})
+
+	r.destroy()
+}
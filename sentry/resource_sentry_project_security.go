@@ -0,0 +1,324 @@
+package sentry
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+const (
+	projectOptionCSPIgnoredSources         = "sentry:csp_ignored_sources"
+	projectOptionCSPIgnoredSourcesDefaults = "sentry:csp_ignored_sources_defaults"
+)
+
+func resourceSentryProjectSecurity() *schema.Resource {
+	return &schema.Resource{
+		Description: "Sentry Project Security resource. It manages the allowed domains, JavaScript source fetching " +
+			"and security header report settings of a project, and resets them when deleted.",
+
+		CreateContext: resourceSentryProjectSecurityUpdate,
+		ReadContext:   resourceSentryProjectSecurityRead,
+		UpdateContext: resourceSentryProjectSecurityUpdate,
+		DeleteContext: resourceSentryProjectSecurityDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importOrganizationScopedID,
+		},
+		CustomizeDiff: customdiff.All(
+			customizeDiffOrganization,
+			customdiff.ComputedIf("expect_ct_report_uri", func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
+				return d.HasChange("expect_ct_report_environment") || d.HasChange("expect_ct_report_release")
+			}),
+		),
+
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Description: "The slug of the organization the project belongs to. Defaults to the provider `organization`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"project": {
+				Description: "The slug of the project to manage the security settings of.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"allowed_domains": {
+				Description: "The origins events and security reports are accepted from, such as " +
+					"`https://example.com` or `*.example.com`. Use `*` to accept all origins. Defaults to `*`.",
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				DiffSuppressFunc: suppressDefaultAllowedDomains,
+			},
+			"scrape_javascript": {
+				Description: "Whether Sentry fetches JavaScript source files and source maps from the web.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"security_token": {
+				Description: "The token Sentry sends in the `security_token_header` when fetching source files, so " +
+					"that servers can restrict access to Sentry. A token is generated when unset.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[-a-zA-Z0-9+/=\s]{1,255}$`), "must be a base64 or hexadecimal token"),
+			},
+			"security_token_header": {
+				Description: "The header Sentry sends the `security_token` in, such as `X-Sentry-Token`. Defaults to " +
+					"`X-Sentry-Token` when empty.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[\w-]{0,20}$`), "must be a header name of at most 20 characters"),
+			},
+			"verify_ssl": {
+				Description: "Whether Sentry verifies the TLS certificates of the servers it fetches source files from.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"csp_ignored_sources_defaults": {
+				Description: "Whether to ignore the Content Security Policy reports of sources known to be noisy, such " +
+					"as browser extensions.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"csp_ignored_sources": {
+				Description: "Additional sources to ignore the Content Security Policy reports of, such as " +
+					"`https://ads.example.com` or `*.example.net`.",
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"expect_ct_report_environment": {
+				Description: "The environment to report the Certificate Transparency failures sent to the " +
+					"`expect_ct_report_uri` in.",
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"expect_ct_report_release": {
+				Description: "The release to report the Certificate Transparency failures sent to the " +
+					"`expect_ct_report_uri` in.",
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"expect_ct_report_uri": {
+				Description: "The URI to set as the `report-uri` of the `Expect-CT` header, built from the first " +
+					"active key of the project. Empty when the project has no active key.",
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSentryProjectSecurityRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderData).ClientWithContext(ctx)
+
+	org, project, err := splitTwoPartID(d.Id(), "organization-slug", "project-slug")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Debug(ctx, "Reading project security", map[string]interface{}{
+		"org":     org,
+		"project": project,
+	})
+	proj, _, err := client.Projects.Get(ctx, org, project)
+	if found, diags := checkClientGet(ctx, err, d); !found {
+		return diags
+	}
+
+	ignoredSourcesDefaults := true
+	if v, ok := proj.Options[projectOptionCSPIgnoredSourcesDefaults].(bool); ok {
+		ignoredSourcesDefaults = v
+	}
+	ignoredSources, _ := proj.Options[projectOptionCSPIgnoredSources].(string)
+
+	keys, _, err := client.ProjectKeys.List(ctx, org, project, nil)
+	if err != nil {
+		return diagFromAPIErr(err, nil, nil)
+	}
+	reportURI, err := expectCTReportURI(keys, d.Get("expect_ct_report_environment").(string), d.Get("expect_ct_report_release").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	retErr := multierror.Append(
+		d.Set("organization", org),
+		d.Set("project", project),
+		d.Set("allowed_domains", proj.AllowedDomains),
+		d.Set("scrape_javascript", proj.ScrapeJavaScript),
+		d.Set("security_token", proj.SecurityToken),
+		d.Set("security_token_header", sentry.StringValue(proj.SecurityTokenHeader)),
+		d.Set("verify_ssl", proj.VerifySSL),
+		d.Set("csp_ignored_sources_defaults", ignoredSourcesDefaults),
+		d.Set("csp_ignored_sources", splitLines(ignoredSources)),
+		d.Set("expect_ct_report_uri", reportURI),
+	)
+	return diag.FromErr(retErr.ErrorOrNil())
+}
+
+func resourceSentryProjectSecurityUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderData).ClientWithContext(ctx)
+
+	org := d.Get("organization").(string)
+	project := d.Get("project").(string)
+	params := &projectSecurityParams{
+		AllowedDomains:      expandStringList(d.Get("allowed_domains").([]interface{})),
+		ScrapeJavaScript:    sentry.Bool(d.Get("scrape_javascript").(bool)),
+		SecurityTokenHeader: sentry.String(d.Get("security_token_header").(string)),
+		VerifySSL:           sentry.Bool(d.Get("verify_ssl").(bool)),
+		Options: map[string]interface{}{
+			projectOptionCSPIgnoredSourcesDefaults: d.Get("csp_ignored_sources_defaults").(bool),
+			projectOptionCSPIgnoredSources:         strings.Join(expandStringList(d.Get("csp_ignored_sources").([]interface{})), "\n"),
+		},
+	}
+	if len(params.AllowedDomains) == 0 {
+		params.AllowedDomains = []string{"*"}
+	}
+	if v, ok := d.GetOk("security_token"); ok {
+		params.SecurityToken = sentry.String(v.(string))
+	} else {
+		// The token of the project is kept, unless it has none yet.
+		proj, _, err := client.Projects.Get(ctx, org, project)
+		if err != nil {
+			return diagFromAPIErr(err, nil, nil)
+		}
+		if proj.SecurityToken == "" {
+			token, err := newSecurityToken()
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			params.SecurityToken = sentry.String(token)
+		}
+	}
+
+	tflog.Debug(ctx, "Updating project security", map[string]interface{}{
+		"org":     org,
+		"project": project,
+	})
+	if err := updateProjectSecurity(ctx, client, org, project, params); err != nil {
+		return diagFromAPIErr(err, resourceSentryProjectSecurity(), map[string]string{
+			"allowedDomains":      "allowed_domains",
+			"scrapeJavaScript":    "scrape_javascript",
+			"securityToken":       "security_token",
+			"securityTokenHeader": "security_token_header",
+			"verifySSL":           "verify_ssl",
+		})
+	}
+
+	d.SetId(buildTwoPartID(org, project))
+	return resourceSentryProjectSecurityRead(ctx, d, meta)
+}
+
+func resourceSentryProjectSecurityDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderData).ClientWithContext(ctx)
+
+	org, project, err := splitTwoPartID(d.Id(), "organization-slug", "project-slug")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The security token is kept, as servers may still expect it.
+	tflog.Debug(ctx, "Resetting project security", map[string]interface{}{
+		"org":     org,
+		"project": project,
+	})
+	err = updateProjectSecurity(ctx, client, org, project, &projectSecurityParams{
+		AllowedDomains:      []string{"*"},
+		ScrapeJavaScript:    sentry.Bool(true),
+		SecurityTokenHeader: sentry.String(""),
+		VerifySSL:           sentry.Bool(false),
+		Options: map[string]interface{}{
+			projectOptionCSPIgnoredSourcesDefaults: true,
+			projectOptionCSPIgnoredSources:         "",
+		},
+	})
+	return checkClientDelete(ctx, err, d)
+}
+
+// projectSecurityParams updates the security settings of a project, which
+// sentry.UpdateProjectParams does not support.
+type projectSecurityParams struct {
+	AllowedDomains      []string               `json:"allowedDomains,omitempty"`
+	ScrapeJavaScript    *bool                  `json:"scrapeJavaScript,omitempty"`
+	SecurityToken       *string                `json:"securityToken,omitempty"`
+	SecurityTokenHeader *string                `json:"securityTokenHeader,omitempty"`
+	VerifySSL           *bool                  `json:"verifySSL,omitempty"`
+	Options             map[string]interface{} `json:"options,omitempty"`
+}
+
+func updateProjectSecurity(ctx context.Context, client *sentry.Client, org, project string, params *projectSecurityParams) error {
+	req, err := client.NewRequest(http.MethodPut, fmt.Sprintf("0/projects/%v/%v/", org, project), params)
+	if err != nil {
+		return err
+	}
+	_, err = client.Do(ctx, req, nil)
+	return err
+}
+
+// expectCTReportURI returns the security report endpoint of the first active
+// key, tagging the reports with the environment and release when set.
+func expectCTReportURI(keys []*sentry.ProjectKey, environment, release string) (string, error) {
+	for _, key := range keys {
+		if !key.IsActive || key.DSN.Security == "" {
+			continue
+		}
+		u, err := url.Parse(key.DSN.Security)
+		if err != nil {
+			return "", err
+		}
+		q := u.Query()
+		if environment != "" {
+			q.Set("sentry_environment", environment)
+		}
+		if release != "" {
+			q.Set("sentry_release", release)
+		}
+		u.RawQuery = q.Encode()
+		return u.String(), nil
+	}
+	return "", nil
+}
+
+// newSecurityToken returns a random token in the format Sentry generates.
+func newSecurityToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// suppressDefaultAllowedDomains suppresses the diff between unset allowed
+// domains and the `*` Sentry stores instead.
+func suppressDefaultAllowedDomains(k, old, new string, d *schema.ResourceData) bool {
+	o, _ := d.GetChange("allowed_domains")
+	if domains := o.([]interface{}); len(domains) != 1 || domains[0] != "*" {
+		return false
+	}
+	return (k == "allowed_domains.#" && new == "0") || (k == "allowed_domains.0" && new == "")
+}
@@ -0,0 +1,110 @@
+package sentry
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+)
+
+func TestUnitSentryProjectSecurity_basic(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+	srv.AddProject(testUnitOrganization, "team", "tf-project")
+	r := newTestUnitResource(t, p, "sentry_project_security").withConfig(map[string]interface{}{
+		"organization": testUnitOrganization,
+		"project":      "tf-project",
+	})
+
+	// The defaults of Sentry produce no changes, and the token of the
+	// project is kept.
+	r.step(nil, map[string]string{
+		"id":                           buildTwoPartID(testUnitOrganization, "tf-project"),
+		"allowed_domains.#":            "1",
+		"allowed_domains.0":            "*",
+		"scrape_javascript":            "true",
+		"verify_ssl":                   "false",
+		"csp_ignored_sources_defaults": "true",
+		"csp_ignored_sources.#":        "0",
+		"expect_ct_report_uri":         "",
+	})
+	token := r.state.Attributes["security_token"]
+	if token == "" {
+		t.Fatal("security_token is not set")
+	}
+
+	config := map[string]interface{}{
+		"allowed_domains":              []interface{}{"https://example.com", "*.example.net"},
+		"scrape_javascript":            false,
+		"security_token":               "0123456789abcdef",
+		"security_token_header":        "X-Example-Token",
+		"verify_ssl":                   true,
+		"csp_ignored_sources_defaults": false,
+		"csp_ignored_sources":          []interface{}{"https://ads.example.com", "*.example.org"},
+		"expect_ct_report_environment": "production",
+		"expect_ct_report_release":     "web@1.0.0",
+	}
+
+	// The Expect-CT report URI uses the key of the project.
+	key := newTestUnitResource(t, p, "sentry_key")
+	key.apply(map[string]interface{}{
+		"organization": testUnitOrganization,
+		"project":      "tf-project",
+		"name":         "tf-key",
+	})
+	reportURI, err := url.Parse(key.state.Attributes["dsn_public"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	reportURI.RawQuery = url.Values{
+		"sentry_environment": {"production"},
+		"sentry_key":         {key.state.Attributes["public"]},
+		"sentry_release":     {"web@1.0.0"},
+	}.Encode()
+	reportURI.Path = fmt.Sprintf("/api%s/security/", reportURI.Path)
+	reportURI.User = nil
+
+	r.step(config, map[string]string{
+		"allowed_domains.#":            "2",
+		"allowed_domains.1":            "*.example.net",
+		"scrape_javascript":            "false",
+		"security_token":               "0123456789abcdef",
+		"security_token_header":        "X-Example-Token",
+		"verify_ssl":                   "true",
+		"csp_ignored_sources_defaults": "false",
+		"csp_ignored_sources.#":        "2",
+		"csp_ignored_sources.1":        "*.example.org",
+		"expect_ct_report_uri":         reportURI.String(),
+	})
+
+	// The report tags are not stored in Sentry.
+	r.checkImport(buildTwoPartID(testUnitOrganization, "tf-project"), "expect_ct_report_")
+
+	// Deleting the resource resets the settings but keeps the token.
+	prev := r.destroy()
+	refreshed := r.importState(prev.ID)
+	for k, want := range map[string]string{
+		"allowed_domains.0":            "*",
+		"scrape_javascript":            "true",
+		"security_token":               "0123456789abcdef",
+		"security_token_header":        "",
+		"verify_ssl":                   "false",
+		"csp_ignored_sources_defaults": "true",
+		"csp_ignored_sources.#":        "0",
+	} {
+		if got := refreshed.Attributes[k]; got != want {
+			t.Errorf("attribute %s after destroy: got %q, want %q", k, got, want)
+		}
+	}
+}
+
+func TestUnitSentryProjectSecurity_invalidHeader(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+	srv.AddProject(testUnitOrganization, "team", "tf-project")
+	r := newTestUnitResource(t, p, "sentry_project_security")
+
+	r.expectPlanError(map[string]interface{}{
+		"project":               "tf-project",
+		"security_token_header": "X-Sentry-Token: secret",
+	}, "must be a header name of at most 20 characters")
+}
@@ -0,0 +1,217 @@
+package sentry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+func resourceSentryProjectServiceHook() *schema.Resource {
+	return &schema.Resource{
+		Description: "Sentry Project Service Hook resource. It sends the events of a project to a URL, signed " +
+			"with the generated `secret`.",
+
+		CreateContext: resourceSentryProjectServiceHookCreate,
+		ReadContext:   resourceSentryProjectServiceHookRead,
+		UpdateContext: resourceSentryProjectServiceHookUpdate,
+		DeleteContext: resourceSentryProjectServiceHookDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importOrganizationProjectScopedID,
+		},
+		CustomizeDiff: customizeDiffOrganization,
+
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Description: "The slug of the organization the project belongs to. Defaults to the provider `organization`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"project": {
+				Description: "The slug of the project to send the events of.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"url": {
+				Description:  "The URL to send the events to.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"events": {
+				Description: "The events to send: `event.alert` when an alert rule fires, and `event.created` for " +
+					"every new event.",
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"event.alert", "event.created"}, false),
+				},
+			},
+			"internal_id": {
+				Description: "The ID of the service hook.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"status": {
+				Description:  "The status of the service hook: `active` to send the events, or `disabled` to stop sending them.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"active", "disabled"}, false),
+			},
+			"secret": {
+				Description: "The secret used to sign the requests sent to the URL.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func resourceSentryProjectServiceHookCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderData).ClientWithContext(ctx)
+
+	org := d.Get("organization").(string)
+	project := d.Get("project").(string)
+	params := expandProjectServiceHookParams(d)
+
+	tflog.Debug(ctx, "Creating project service hook", map[string]interface{}{
+		"org":     org,
+		"project": project,
+	})
+	hook, err := requestProjectServiceHook(ctx, client, http.MethodPost, fmt.Sprintf("0/projects/%v/%v/hooks/", org, project), params)
+	if err != nil {
+		return diagFromAPIErr(err, resourceSentryProjectServiceHook(), nil)
+	}
+
+	d.SetId(buildThreePartID(org, project, hook.ID))
+	return resourceSentryProjectServiceHookRead(ctx, d, meta)
+}
+
+func resourceSentryProjectServiceHookRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderData).ClientWithContext(ctx)
+
+	org, project, id, err := splitThreePartID(d.Id(), "organization-slug", "project-slug", "hook-id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Debug(ctx, "Reading project service hook", map[string]interface{}{
+		"org":     org,
+		"project": project,
+		"id":      id,
+	})
+	hook, err := requestProjectServiceHook(ctx, client, http.MethodGet, fmt.Sprintf("0/projects/%v/%v/hooks/%v/", org, project, id), nil)
+	if found, diags := checkClientGet(ctx, err, d); !found {
+		return diags
+	}
+
+	retErr := multierror.Append(
+		d.Set("organization", org),
+		d.Set("project", project),
+		d.Set("url", hook.URL),
+		d.Set("events", flattenStringSet(hook.Events)),
+		d.Set("internal_id", hook.ID),
+		d.Set("status", hook.Status),
+		d.Set("secret", hook.Secret),
+	)
+	return diag.FromErr(retErr.ErrorOrNil())
+}
+
+func resourceSentryProjectServiceHookUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderData).ClientWithContext(ctx)
+
+	org, project, id, err := splitThreePartID(d.Id(), "organization-slug", "project-slug", "hook-id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	params := expandProjectServiceHookParams(d)
+
+	tflog.Debug(ctx, "Updating project service hook", map[string]interface{}{
+		"org":     org,
+		"project": project,
+		"id":      id,
+	})
+	_, err = requestProjectServiceHook(ctx, client, http.MethodPut, fmt.Sprintf("0/projects/%v/%v/hooks/%v/", org, project, id), params)
+	if err != nil {
+		return diagFromAPIErr(err, resourceSentryProjectServiceHook(), nil)
+	}
+
+	return resourceSentryProjectServiceHookRead(ctx, d, meta)
+}
+
+func resourceSentryProjectServiceHookDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderData).ClientWithContext(ctx)
+
+	org, project, id, err := splitThreePartID(d.Id(), "organization-slug", "project-slug", "hook-id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Debug(ctx, "Deleting project service hook", map[string]interface{}{
+		"org":     org,
+		"project": project,
+		"id":      id,
+	})
+	req, err := client.NewRequest(http.MethodDelete, fmt.Sprintf("0/projects/%v/%v/hooks/%v/", org, project, id), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, err = client.Do(ctx, req, nil)
+	return checkClientDelete(ctx, err, d)
+}
+
+// projectServiceHook is a service hook of a project, which go-sentry does
+// not support.
+type projectServiceHook struct {
+	ID     string   `json:"id"`
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Status string   `json:"status"`
+	Secret string   `json:"secret"`
+}
+
+type projectServiceHookParams struct {
+	URL      string   `json:"url"`
+	Events   []string `json:"events"`
+	IsActive *bool    `json:"isActive,omitempty"`
+}
+
+func expandProjectServiceHookParams(d *schema.ResourceData) *projectServiceHookParams {
+	params := &projectServiceHookParams{
+		URL:    d.Get("url").(string),
+		Events: expandStringList(d.Get("events").(*schema.Set).List()),
+	}
+	if v, ok := d.GetOk("status"); ok {
+		params.IsActive = sentry.Bool(v.(string) == "active")
+	}
+	return params
+}
+
+func requestProjectServiceHook(ctx context.Context, client *sentry.Client, method, url string, params *projectServiceHookParams) (*projectServiceHook, error) {
+	var body interface{}
+	if params != nil {
+		body = params
+	}
+	req, err := client.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	hook := new(projectServiceHook)
+	if _, err := client.Do(ctx, req, hook); err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
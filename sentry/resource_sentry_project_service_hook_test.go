@@ -0,0 +1,81 @@
+package sentry
+
+import (
+	"testing"
+)
+
+func TestUnitSentryProjectServiceHook_basic(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+	srv.AddProject(testUnitOrganization, "team", "tf-project")
+	r := newTestUnitResource(t, p, "sentry_project_service_hook").withConfig(map[string]interface{}{
+		"organization": testUnitOrganization,
+		"project":      "tf-project",
+	})
+
+	r.step(map[string]interface{}{
+		"url":    "https://example.com/hooks/sentry",
+		"events": []interface{}{"event.alert"},
+	}, map[string]string{
+		"url":      "https://example.com/hooks/sentry",
+		"events.#": "1",
+		"status":   "active",
+	})
+	r.checkAttrs(map[string]string{
+		"id": buildThreePartID(testUnitOrganization, "tf-project", r.state.Attributes["internal_id"]),
+	})
+	secret := r.state.Attributes["secret"]
+	if secret == "" {
+		t.Error("secret is not set")
+	}
+	if !p.ResourcesMap["sentry_project_service_hook"].Schema["secret"].Sensitive {
+		t.Error("secret is not sensitive")
+	}
+
+	// Updating the hook keeps its ID and secret.
+	id := r.state.ID
+	config := map[string]interface{}{
+		"url":    "https://example.com/hooks/sentry-v2",
+		"events": []interface{}{"event.alert", "event.created"},
+	}
+	r.apply(config)
+	r.checkAttrs(map[string]string{
+		"id":       id,
+		"url":      "https://example.com/hooks/sentry-v2",
+		"events.#": "2",
+		"secret":   secret,
+	})
+
+	// Disabling the hook and enabling it again.
+	for _, status := range []string{"disabled", "active"} {
+		config["status"] = status
+		r.step(config, map[string]string{
+			"id":     id,
+			"status": status,
+		})
+	}
+
+	r.checkImport(id)
+
+	r.expectGone(r.destroy())
+}
+
+func TestUnitSentryProjectServiceHook_invalidEvent(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+	srv.AddProject(testUnitOrganization, "team", "tf-project")
+	r := newTestUnitResource(t, p, "sentry_project_service_hook")
+
+	r.expectPlanError(map[string]interface{}{
+		"project": "tf-project",
+		"url":     "https://example.com/hooks/sentry",
+		"events":  []interface{}{"event.resolved"},
+	}, "expected events.0 to be one of")
+
+	r.expectPlanError(map[string]interface{}{
+		"project": "tf-project",
+		"url":     "https://example.com/hooks/sentry",
+		"events":  []interface{}{"event.alert"},
+		"status":  "paused",
+	}, "expected status to be one of")
+}
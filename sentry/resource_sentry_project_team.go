@@ -0,0 +1,129 @@
+package sentry
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+func resourceSentryProjectTeam() *schema.Resource {
+	return &schema.Resource{
+		Description: "Sentry Project Team resource. It gives a team access to a project. Set " +
+			"`ignore_team_membership` on the `sentry_project` so that it does not remove the team.",
+
+		CreateContext: resourceSentryProjectTeamCreate,
+		ReadContext:   resourceSentryProjectTeamRead,
+		DeleteContext: resourceSentryProjectTeamDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importOrganizationProjectScopedID,
+		},
+		CustomizeDiff: customizeDiffOrganization,
+
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Description: "The slug of the organization the project belongs to. Defaults to the provider `organization`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"project": {
+				Description: "The slug of the project.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"team": {
+				Description: "The slug of the team to give access to the project.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"team_id": {
+				Description: "The internal ID of the team.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceSentryProjectTeamCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderData).ClientWithContext(ctx)
+
+	org := d.Get("organization").(string)
+	project := d.Get("project").(string)
+	team := d.Get("team").(string)
+
+	tflog.Debug(ctx, "Adding team to project", map[string]interface{}{
+		"org":     org,
+		"project": project,
+		"team":    team,
+	})
+	if _, _, err := client.Projects.AddTeam(ctx, org, project, team); err != nil {
+		return diagFromAPIErr(err, nil, nil)
+	}
+
+	d.SetId(buildThreePartID(org, project, team))
+	return resourceSentryProjectTeamRead(ctx, d, meta)
+}
+
+func resourceSentryProjectTeamRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderData).ClientWithContext(ctx)
+
+	org, project, team, err := splitThreePartID(d.Id(), "organization-slug", "project-slug", "team-slug")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Debug(ctx, "Reading project team", map[string]interface{}{
+		"org":     org,
+		"project": project,
+		"team":    team,
+	})
+	proj, _, err := client.Projects.Get(ctx, org, project)
+	if found, diags := checkClientGet(ctx, err, d); !found {
+		return diags
+	}
+
+	for _, t := range proj.Teams {
+		if sentry.StringValue(t.Slug) == team {
+			retErr := multierror.Append(
+				d.Set("organization", org),
+				d.Set("project", project),
+				d.Set("team", team),
+				d.Set("team_id", t.ID),
+			)
+			return diag.FromErr(retErr.ErrorOrNil())
+		}
+	}
+
+	tflog.Warn(ctx, "Team no longer has access to the project, removing from state", map[string]interface{}{
+		"org":     org,
+		"project": project,
+		"team":    team,
+	})
+	d.SetId("")
+	return nil
+}
+
+func resourceSentryProjectTeamDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderData).ClientWithContext(ctx)
+
+	org, project, team, err := splitThreePartID(d.Id(), "organization-slug", "project-slug", "team-slug")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Debug(ctx, "Removing team from project", map[string]interface{}{
+		"org":     org,
+		"project": project,
+		"team":    team,
+	})
+	_, err = client.Projects.RemoveTeam(ctx, org, project, team)
+	return checkClientDelete(ctx, err, d)
+}
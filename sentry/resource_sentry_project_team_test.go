@@ -0,0 +1,47 @@
+package sentry
+
+import (
+	"testing"
+)
+
+func TestUnitSentryProjectTeam_basic(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "owners")
+	srv.AddTeam(testUnitOrganization, "backend")
+	project := newTestUnitResource(t, p, "sentry_project")
+	projectTeam := newTestUnitResource(t, p, "sentry_project_team")
+
+	projectConfig := map[string]interface{}{
+		"organization":           testUnitOrganization,
+		"teams":                  []interface{}{"owners"},
+		"name":                   "tf-project",
+		"slug":                   "tf-project",
+		"ignore_team_membership": true,
+	}
+	project.apply(projectConfig)
+
+	projectTeamConfig := map[string]interface{}{
+		"organization": testUnitOrganization,
+		"project":      "tf-project",
+		"team":         "backend",
+	}
+	projectTeam.apply(projectTeamConfig)
+	projectTeam.checkAttrs(map[string]string{
+		"id":   buildThreePartID(testUnitOrganization, "tf-project", "backend"),
+		"team": "backend",
+	})
+	projectTeam.checkImport(projectTeam.state.ID)
+
+	// The project does not fight over the team added by sentry_project_team.
+	project.refresh()
+	project.checkAttrs(map[string]string{
+		"teams.#": "1",
+	})
+	project.expectNoChanges(projectConfig)
+
+	// Nor over changes to its own teams.
+	projectConfig["teams"] = []interface{}{"owners", "frontend"}
+	project.expectNoChanges(projectConfig)
+
+	projectTeam.expectGone(projectTeam.destroy())
+}
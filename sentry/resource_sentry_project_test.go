@@ -2,6 +2,7 @@ package sentry
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"regexp"
@@ -9,10 +10,10 @@ import (
 	"strings"
 	"testing"
 
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	"github.com/jianyuan/go-sentry/v2/sentry"
 )
 
 func TestAccSentryProject_basic(t *testing.T) {
@@ -248,7 +249,7 @@ func TestAccSentryProject_changeTeam(t *testing.T) {
 }
 
 func testAccCheckSentryProjectDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*sentry.Client)
+	client := testAccProvider.Meta().(*ProviderData).Client
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "sentry_project" {
@@ -281,7 +282,7 @@ func testAccCheckSentryProjectExists(n string, projectID *string) resource.TestC
 			return errors.New("no ID is set")
 		}
 
-		client := testAccProvider.Meta().(*sentry.Client)
+		client := testAccProvider.Meta().(*ProviderData).Client
 		ctx := context.Background()
 		gotProj, _, err := client.Projects.Get(
 			ctx,
@@ -417,3 +418,301 @@ resource "sentry_project" "test" {
 
 	return config
 }
+
+func TestUnitSentryProject_basic(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team-a")
+	srv.AddTeam(testUnitOrganization, "team-b")
+	r := newTestUnitResource(t, p, "sentry_project").withConfig(map[string]interface{}{
+		"organization": testUnitOrganization,
+		"teams":        []interface{}{"team-a"},
+	})
+
+	r.apply(map[string]interface{}{
+		"name": "tf-project",
+		"slug": "tf-project",
+	})
+	r.checkAttrs(map[string]string{
+		"id":                "tf-project",
+		"organization":      testUnitOrganization,
+		"name":              "tf-project",
+		"slug":              "tf-project",
+		"teams.#":           "1",
+		"digests_min_delay": "300",
+		"digests_max_delay": "1800",
+	})
+	if r.state.Attributes["internal_id"] == "" {
+		t.Error("internal_id is not set")
+	}
+
+	config := map[string]interface{}{
+		"name":              "tf-project-renamed",
+		"slug":              "tf-project-renamed",
+		"digests_min_delay": 100,
+		"digests_max_delay": 200,
+		"resolve_age":       24,
+	}
+	r.apply(config)
+	r.checkAttrs(map[string]string{
+		"id":                "tf-project-renamed",
+		"name":              "tf-project-renamed",
+		"slug":              "tf-project-renamed",
+		"teams.#":           "1",
+		"digests_min_delay": "100",
+		"digests_max_delay": "200",
+		"resolve_age":       "24",
+	})
+
+	config["teams"] = []interface{}{"team-b"}
+	r.step(config, map[string]string{"teams.#": "1"})
+	for k, v := range r.state.Attributes {
+		if strings.HasPrefix(k, "teams.") && v == "team-a" {
+			t.Errorf("team-a should have been removed from the project")
+		}
+	}
+	r.checkImport(buildTwoPartID(testUnitOrganization, "tf-project-renamed"))
+
+	r.expectGone(r.destroy())
+}
+
+func TestUnitSentryProject_options(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+	r := newTestUnitResource(t, p, "sentry_project").withConfig(map[string]interface{}{
+		"organization": testUnitOrganization,
+		"teams":        []interface{}{"team"},
+		"name":         "tf-project",
+		"slug":         "tf-project",
+	})
+
+	r.apply(map[string]interface{}{
+		"options": map[string]interface{}{
+			"sentry:scrape_javascript": "false",
+			"sentry:resolve_age":       "720",
+			"sentry:token":             "abc",
+			"sentry:token_header":      "1234",
+			"mail:subject_prefix":      "true",
+			"sentry:relay_pii_config":  `{"applications": {"$string": ["@ip"]}}`,
+		},
+	})
+	r.checkAttrs(map[string]string{
+		"options.%":                        "6",
+		"options.sentry:scrape_javascript": "false",
+		"options.sentry:resolve_age":       "720",
+		"options.sentry:token":             "abc",
+		"options.sentry:token_header":      "1234",
+		"options.mail:subject_prefix":      "true",
+	})
+	// Only the options known to be booleans or numbers are converted.
+	for key, want := range map[string]interface{}{
+		"sentry:scrape_javascript": false,
+		"sentry:resolve_age":       float64(720),
+		"sentry:token":             "abc",
+		"sentry:token_header":      "1234",
+		"mail:subject_prefix":      "true",
+		"sentry:relay_pii_config":  `{"applications": {"$string": ["@ip"]}}`,
+	} {
+		if got, _ := srv.ProjectOption(testUnitOrganization, "tf-project", key); got != want {
+			t.Errorf("option %s: got %#v, want %#v", key, got, want)
+		}
+	}
+
+	// Options not set in the configuration are not tracked.
+	srv.SetProjectOption(testUnitOrganization, "tf-project", "sentry:origins", "*")
+	r.refresh()
+	if _, ok := r.state.Attributes["options.sentry:origins"]; ok {
+		t.Error("untracked option sentry:origins should not be in state")
+	}
+
+	// JSON values are compared semantically.
+	r.expectNoChanges(map[string]interface{}{
+		"options": map[string]interface{}{
+			"sentry:scrape_javascript": "false",
+			"sentry:resolve_age":       "720",
+			"sentry:token":             "abc",
+			"sentry:token_header":      "1234",
+			"mail:subject_prefix":      "true",
+			"sentry:relay_pii_config":  `{"applications":{"$string":["@ip"]}}`,
+		},
+	})
+
+	diags := r.expectApplyError(map[string]interface{}{
+		"options": map[string]interface{}{"sentry:resolve_age": "a month"},
+	})
+	if want := "invalid value of option sentry:resolve_age"; !strings.Contains(diags[0].Summary, want) {
+		t.Errorf("expected %q, got %q", want, diags[0].Summary)
+	}
+
+	// Options removed from the configuration are reset.
+	r.apply(map[string]interface{}{
+		"options": map[string]interface{}{"sentry:scrape_javascript": "true"},
+	})
+	r.checkAttrs(map[string]string{
+		"options.%":                        "1",
+		"options.sentry:scrape_javascript": "true",
+	})
+	if _, ok := srv.ProjectOption(testUnitOrganization, "tf-project", "sentry:token"); ok {
+		t.Error("option sentry:token should have been reset")
+	}
+	if got, _ := srv.ProjectOption(testUnitOrganization, "tf-project", "sentry:origins"); got != "*" {
+		t.Errorf("untracked option sentry:origins should be left alone, got %#v", got)
+	}
+
+	r.checkImport(buildTwoPartID(testUnitOrganization, "tf-project"), "options.")
+}
+
+func TestUnitSentryProject_defaultRules(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+
+	for _, tc := range []struct {
+		slug      string
+		config    map[string]interface{}
+		wantRules int
+		adopted   bool
+	}{
+		{slug: "default", wantRules: 1},
+		{slug: "no-default-rules", config: map[string]interface{}{"default_rules": false}},
+		{slug: "adopt", config: map[string]interface{}{"default_issue_alert": "adopt"}, wantRules: 1, adopted: true},
+		{slug: "delete", config: map[string]interface{}{"default_issue_alert": "delete"}},
+	} {
+		t.Run(tc.slug, func(t *testing.T) {
+			r := newTestUnitResource(t, p, "sentry_project").withConfig(map[string]interface{}{
+				"organization": testUnitOrganization,
+				"team":         "team",
+				"name":         tc.slug,
+				"slug":         tc.slug,
+			})
+			r.apply(tc.config)
+
+			ids := srv.IssueAlertIDs(testUnitOrganization, tc.slug)
+			if len(ids) != tc.wantRules {
+				t.Fatalf("got %d issue alerts, want %d", len(ids), tc.wantRules)
+			}
+			want := ""
+			if tc.adopted {
+				want = ids[0]
+			}
+			r.checkAttrs(map[string]string{"default_issue_alert_id": want})
+
+			// Create-time attributes do not cause changes afterwards.
+			r.expectNoChanges(map[string]interface{}{"default_rules": false})
+		})
+	}
+}
+
+func TestUnitSentryProject_slugChangedOutside(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+	r := newTestUnitResource(t, p, "sentry_project").withConfig(map[string]interface{}{
+		"organization": testUnitOrganization,
+		"teams":        []interface{}{"team"},
+		"name":         "tf-project",
+	})
+
+	r.apply(nil)
+	internalID := r.state.Attributes["internal_id"]
+
+	// The project is followed by its internal ID rather than recreated.
+	srv.RenameProject(testUnitOrganization, "tf-project", "tf-project-renamed")
+	r.refresh()
+	r.checkAttrs(map[string]string{
+		"id":          "tf-project-renamed",
+		"slug":        "tf-project-renamed",
+		"internal_id": internalID,
+	})
+	r.expectNoChanges(nil)
+
+	// A project that is gone is still removed from state.
+	r.expectGone(r.destroy())
+}
+
+func TestUnitSentryProject_slugAndTeamsChanged(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team-a")
+	srv.AddTeam(testUnitOrganization, "team-b")
+	r := newTestUnitResource(t, p, "sentry_project").withConfig(map[string]interface{}{
+		"organization": testUnitOrganization,
+		"name":         "tf-project",
+	})
+
+	r.apply(map[string]interface{}{
+		"teams": []interface{}{"team-a"},
+		"slug":  "tf-project",
+	})
+
+	// The teams are changed through the new slug, in the same apply.
+	r.step(map[string]interface{}{
+		"teams": []interface{}{"team-b"},
+		"slug":  "tf-project-renamed",
+	}, map[string]string{
+		"id":      "tf-project-renamed",
+		"teams.#": "1",
+	})
+	for k, v := range r.state.Attributes {
+		if strings.HasPrefix(k, "teams.") && v != "team-b" && k != "teams.#" {
+			t.Errorf("unexpected team %s", v)
+		}
+	}
+}
+
+func TestUnitSentryProject_stateUpgradeV0(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	srv.AddTeam(testUnitOrganization, "team")
+	srv.AddProject(testUnitOrganization, "team", "tf-project")
+
+	// State as written by the provider before the schema version was
+	// introduced, without the attributes added since.
+	v0 := []byte(`{
+		"id": "tf-project",
+		"organization": "` + testUnitOrganization + `",
+		"team": null,
+		"teams": ["team"],
+		"name": "tf-project",
+		"slug": "tf-project",
+		"platform": "",
+		"internal_id": "",
+		"is_public": false,
+		"is_bookmarked": false,
+		"color": "#3f70bf",
+		"features": [],
+		"status": "active",
+		"digests_min_delay": 300,
+		"digests_max_delay": 1800,
+		"resolve_age": 0,
+		"project_id": ""
+	}`)
+	v0Type := resourceSentryProjectResourceV0().CoreConfigSchema().ImpliedType()
+	if _, err := ctyjson.Unmarshal(v0, v0Type); err != nil {
+		t.Fatalf("v0 state does not match the v0 schema: %s", err)
+	}
+
+	var rawState map[string]interface{}
+	if err := json.Unmarshal(v0, &rawState); err != nil {
+		t.Fatal(err)
+	}
+	rawState, err := resourceSentryProjectStateUpgradeV0(context.Background(), rawState, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	upgraded, err := json.Marshal(rawState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	val, err := ctyjson.Unmarshal(upgraded, resourceSentryProject().CoreConfigSchema().ImpliedType())
+	if err != nil {
+		t.Fatalf("upgraded state does not match the current schema: %s", err)
+	}
+
+	r := newTestUnitResource(t, p, "sentry_project")
+	r.state = terraform.NewInstanceStateShimmedFromValue(val, 1)
+	r.refresh()
+	if r.state.Attributes["internal_id"] == "" {
+		t.Errorf("internal_id not recorded by Read: %#v", r.state.Attributes)
+	}
+	r.expectNoChanges(map[string]interface{}{
+		"organization": testUnitOrganization,
+		"teams":        []interface{}{"team"},
+		"name":         "tf-project",
+	})
+}
@@ -2,7 +2,6 @@ package sentry
 
 import (
 	"context"
-	"net/http"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -22,12 +21,14 @@ func resourceSentryTeam() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: importOrganizationAndID,
 		},
+		CustomizeDiff: customizeDiffOrganization,
 
 		Schema: map[string]*schema.Schema{
 			"organization": {
-				Description: "The slug of the organization the team should be created for.",
+				Description: "The slug of the organization the team should be created for. Defaults to the provider `organization`.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 			},
 			"name": {
 				Description: "The name of the team.",
@@ -68,7 +69,7 @@ func resourceSentryTeam() *schema.Resource {
 }
 
 func resourceSentryTeamCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	org := d.Get("organization").(string)
 	params := &sentry.CreateTeamParams{
@@ -81,7 +82,7 @@ func resourceSentryTeamCreate(ctx context.Context, d *schema.ResourceData, meta
 	tflog.Debug(ctx, "Creating team", map[string]interface{}{"org": org, "teamName": params.Name})
 	team, _, err := client.Teams.Create(ctx, org, params)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIErr(err, resourceSentryTeam(), nil)
 	}
 
 	d.SetId(sentry.StringValue(team.Slug))
@@ -89,22 +90,15 @@ func resourceSentryTeamCreate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceSentryTeamRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	teamSlug := d.Id()
 	org := d.Get("organization").(string)
 
 	tflog.Debug(ctx, "Reading team", map[string]interface{}{"org": org, "team": teamSlug})
 	team, _, err := client.Teams.Get(ctx, org, teamSlug)
-	if err != nil {
-		if sErr, ok := err.(*sentry.ErrorResponse); ok {
-			if sErr.Response.StatusCode == http.StatusNotFound {
-				tflog.Info(ctx, "Removing team from state because it no longer exists in Sentry", map[string]interface{}{"team": teamSlug})
-				d.SetId("")
-				return nil
-			}
-		}
-		return diag.FromErr(err)
+	if found, diags := checkClientGet(ctx, err, d); !found {
+		return diags
 	}
 
 	retErr := multierror.Append(
@@ -121,7 +115,7 @@ func resourceSentryTeamRead(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func resourceSentryTeamUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	teamSlug := d.Id()
 	org := d.Get("organization").(string)
@@ -133,7 +127,7 @@ func resourceSentryTeamUpdate(ctx context.Context, d *schema.ResourceData, meta
 	tflog.Debug(ctx, "Updating team", map[string]interface{}{"org": org, "team": teamSlug})
 	team, _, err := client.Teams.Update(ctx, org, teamSlug, params)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromAPIErr(err, resourceSentryTeam(), nil)
 	}
 
 	d.SetId(sentry.StringValue(team.Slug))
@@ -141,12 +135,12 @@ func resourceSentryTeamUpdate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceSentryTeamDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*ProviderData).ClientWithContext(ctx)
 
 	teamSlug := d.Id()
 	org := d.Get("organization").(string)
 
 	tflog.Debug(ctx, "Deleting team", map[string]interface{}{"org": org, "team": teamSlug})
 	_, err := client.Teams.Delete(ctx, org, teamSlug)
-	return diag.FromErr(err)
+	return checkClientDelete(ctx, err, d)
 }
@@ -57,7 +57,7 @@ func TestAccSentryTeam_basic(t *testing.T) {
 }
 
 func testAccCheckSentryTeamDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*sentry.Client)
+	client := testAccProvider.Meta().(*ProviderData).Client
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "sentry_team" {
@@ -96,7 +96,7 @@ func testAccCheckSentryTeamExists(n string, teamID *string) resource.TestCheckFu
 
 		org := rs.Primary.Attributes["organization"]
 		teamSlug := rs.Primary.ID
-		client := testAccProvider.Meta().(*sentry.Client)
+		client := testAccProvider.Meta().(*ProviderData).Client
 		ctx := context.Background()
 		gotTeam, _, err := client.Teams.Get(ctx, org, teamSlug)
 		if err != nil {
@@ -131,7 +131,10 @@ resource "sentry_team" "test" {
 
 func TestValidatePlatform(t *testing.T) {
 	for _, tc := range []string{
+		"apple-tvos",
+		"javascript-nuxt",
 		"javascript-react",
+		"node-nestjs",
 		"other",
 		"python-aiohttp",
 		"python",
@@ -140,10 +143,35 @@ func TestValidatePlatform(t *testing.T) {
 		tc := tc
 		t.Run(tc, func(t *testing.T) {
 			t.Parallel()
-			diag := validatePlatform(tc, nil)
-			if diag.HasError() {
+			if err := embeddedPlatforms.check(tc); err != nil {
 				t.Errorf("platform should be valid: %v", tc)
 			}
 		})
 	}
 }
+
+func TestUnitSentryTeam_basic(t *testing.T) {
+	p, _ := testUnitProvider(t)
+	r := newTestUnitResource(t, p, "sentry_team").withConfig(map[string]interface{}{
+		"organization": testUnitOrganization,
+	})
+
+	r.step(map[string]interface{}{"name": "tf-team", "slug": "tf-team"}, map[string]string{
+		"id":           "tf-team",
+		"organization": testUnitOrganization,
+		"name":         "tf-team",
+		"slug":         "tf-team",
+		"has_access":   "true",
+		"is_member":    "true",
+		"is_pending":   "false",
+	})
+
+	r.step(map[string]interface{}{"name": "tf-team-renamed", "slug": "tf-team-renamed"}, map[string]string{
+		"id":   "tf-team-renamed",
+		"name": "tf-team-renamed",
+		"slug": "tf-team-renamed",
+	})
+	r.checkImport(buildTwoPartID(testUnitOrganization, "tf-team-renamed"))
+
+	r.expectGone(r.destroy())
+}
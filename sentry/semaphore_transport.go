@@ -0,0 +1,138 @@
+package sentry
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+const (
+	headerConcurrentLimit     = "X-Sentry-Rate-Limit-ConcurrentLimit"
+	headerConcurrentRemaining = "X-Sentry-Rate-Limit-ConcurrentRemaining"
+
+	// defaultMaxConcurrentRequests is the concurrency used until Sentry
+	// reports its concurrent rate limit, which self-hosted instances often
+	// never do.
+	defaultMaxConcurrentRequests = 10
+)
+
+// semaphoreTransport limits the number of requests in flight. The limit starts
+// at DefaultLimit and follows the concurrent rate limit headers Sentry returns
+// on every response, so that the provider backs off when other clients share
+// the same limit and speeds up again when they finish.
+type semaphoreTransport struct {
+	Delegate http.RoundTripper
+
+	// DefaultLimit is the limit used until Sentry reports one.
+	DefaultLimit int
+	// MaxLimit, if positive, caps the limit regardless of what Sentry
+	// reports.
+	MaxLimit int
+
+	mu       sync.Mutex
+	limit    int
+	inFlight int
+	waiters  []chan struct{}
+}
+
+func (t *semaphoreTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.acquire(req); err != nil {
+		return nil, err
+	}
+	defer t.release()
+
+	resp, err := t.Delegate.RoundTrip(req)
+	if resp != nil {
+		t.adapt(resp)
+	}
+	return resp, err
+}
+
+// acquire blocks until a slot is available or the request is cancelled.
+// Waiters are served in order of arrival.
+func (t *semaphoreTransport) acquire(req *http.Request) error {
+	t.mu.Lock()
+	if t.limit == 0 {
+		t.limit = t.clamp(t.DefaultLimit)
+	}
+	if t.inFlight < t.limit && len(t.waiters) == 0 {
+		t.inFlight++
+		t.mu.Unlock()
+		return nil
+	}
+
+	ready := make(chan struct{})
+	t.waiters = append(t.waiters, ready)
+	t.mu.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-req.Context().Done():
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		select {
+		case <-ready:
+			// The slot was granted while the request was being cancelled.
+			t.inFlight--
+			t.grant()
+		default:
+			for i, w := range t.waiters {
+				if w == ready {
+					t.waiters = append(t.waiters[:i], t.waiters[i+1:]...)
+					break
+				}
+			}
+		}
+		return req.Context().Err()
+	}
+}
+
+func (t *semaphoreTransport) release() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.inFlight--
+	t.grant()
+}
+
+// adapt updates the limit from the concurrent rate limit headers. Requests
+// made by other clients count against the same limit, so the share available
+// to this transport is what it has in flight plus what Sentry reports as
+// remaining.
+func (t *semaphoreTransport) adapt(resp *http.Response) {
+	limit, err := strconv.Atoi(resp.Header.Get(headerConcurrentLimit))
+	if err != nil || limit <= 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if remaining, err := strconv.Atoi(resp.Header.Get(headerConcurrentRemaining)); err == nil && t.inFlight+remaining < limit {
+		limit = t.inFlight + remaining
+	}
+	t.limit = t.clamp(limit)
+	t.grant()
+}
+
+// clamp bounds a limit to [1, MaxLimit].
+func (t *semaphoreTransport) clamp(limit int) int {
+	if t.MaxLimit > 0 && limit > t.MaxLimit {
+		limit = t.MaxLimit
+	}
+	if limit < 1 {
+		limit = 1
+	}
+	return limit
+}
+
+// grant hands free slots to waiters. t.mu must be held.
+func (t *semaphoreTransport) grant() {
+	for t.inFlight < t.limit && len(t.waiters) > 0 {
+		t.inFlight++
+		close(t.waiters[0])
+		t.waiters = t.waiters[1:]
+	}
+}
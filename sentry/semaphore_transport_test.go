@@ -0,0 +1,217 @@
+package sentry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jianyuan/terraform-provider-sentry/internal/fakesentry"
+)
+
+// testSemaphoreTransportGet sends n concurrent requests through t and returns
+// the status codes received.
+func testSemaphoreTransportGet(tb testing.TB, t *semaphoreTransport, url string, n int) []int {
+	tb.Helper()
+
+	client := &http.Client{Transport: t}
+	statuses := make([]int, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := client.Get(url)
+			if err != nil {
+				tb.Error(err)
+				return
+			}
+			resp.Body.Close()
+			statuses[i] = resp.StatusCode
+		}(i)
+	}
+	wg.Wait()
+	return statuses
+}
+
+func TestSemaphoreTransport_defaultLimit(t *testing.T) {
+	srv := fakesentry.NewServer()
+	defer srv.Close()
+	srv.SetLatency(20 * time.Millisecond)
+
+	transport := &semaphoreTransport{
+		Delegate:     http.DefaultTransport,
+		DefaultLimit: 4,
+	}
+	testSemaphoreTransportGet(t, transport, srv.BaseURL()+"0/organizations/", 20)
+
+	if got := srv.PeakConcurrentRequests(); got != 4 {
+		t.Errorf("got %d concurrent requests, want 4", got)
+	}
+}
+
+func TestSemaphoreTransport_adaptsToConcurrentLimit(t *testing.T) {
+	srv := fakesentry.NewServer()
+	defer srv.Close()
+	srv.SetLatency(20 * time.Millisecond)
+	srv.SetConcurrentLimit(3)
+
+	transport := &semaphoreTransport{
+		Delegate:     http.DefaultTransport,
+		DefaultLimit: 10,
+	}
+	url := srv.BaseURL() + "0/organizations/"
+	testSemaphoreTransportGet(t, transport, url, 1)
+
+	for _, status := range testSemaphoreTransportGet(t, transport, url, 20) {
+		if status != http.StatusOK {
+			t.Errorf("got status %d, want %d", status, http.StatusOK)
+		}
+	}
+	if got := srv.PeakConcurrentRequests(); got != 3 {
+		t.Errorf("got %d concurrent requests, want 3", got)
+	}
+}
+
+func TestSemaphoreTransport_maxLimit(t *testing.T) {
+	srv := fakesentry.NewServer()
+	defer srv.Close()
+	srv.SetLatency(20 * time.Millisecond)
+	srv.SetConcurrentLimit(10)
+
+	transport := &semaphoreTransport{
+		Delegate:     http.DefaultTransport,
+		DefaultLimit: 10,
+		MaxLimit:     2,
+	}
+	testSemaphoreTransportGet(t, transport, srv.BaseURL()+"0/organizations/", 20)
+
+	if got := srv.PeakConcurrentRequests(); got != 2 {
+		t.Errorf("got %d concurrent requests, want 2", got)
+	}
+}
+
+func TestSemaphoreTransport_sharedLimit(t *testing.T) {
+	tests := []struct {
+		inFlight  int
+		limit     string
+		remaining string
+		want      int
+	}{
+		{0, "25", "", 25},
+		{0, "25", "24", 24},
+		{3, "25", "2", 5},
+		{0, "25", "0", 1},
+		{5, "", "", 10},
+		{5, "invalid", "3", 10},
+	}
+	for _, tt := range tests {
+		transport := &semaphoreTransport{limit: 10, inFlight: tt.inFlight}
+		resp := &http.Response{Header: http.Header{}}
+		resp.Header.Set(headerConcurrentLimit, tt.limit)
+		resp.Header.Set(headerConcurrentRemaining, tt.remaining)
+
+		transport.adapt(resp)
+		if transport.limit != tt.want {
+			t.Errorf("limit %q, remaining %q with %d in flight: got %d, want %d", tt.limit, tt.remaining, tt.inFlight, transport.limit, tt.want)
+		}
+	}
+}
+
+func TestSemaphoreTransport_cancel(t *testing.T) {
+	transport := &semaphoreTransport{
+		Delegate:     http.DefaultTransport,
+		DefaultLimit: 1,
+	}
+	held, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	if err := transport.acquire(held); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.com", nil)
+	if _, err := transport.RoundTrip(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if len(transport.waiters) != 0 {
+		t.Errorf("got %d waiters, want 0", len(transport.waiters))
+	}
+
+	transport.release()
+	if transport.inFlight != 0 {
+		t.Errorf("got %d requests in flight, want 0", transport.inFlight)
+	}
+}
+
+// BenchmarkSemaphoreTransport_refresh measures how many sentry_team resources
+// can be refreshed per second with Terraform's default parallelism, against a
+// server taking 5ms per request.
+func BenchmarkSemaphoreTransport_refresh(b *testing.B) {
+	benchmarks := []struct {
+		name            string
+		maxLimit        int
+		concurrentLimit int
+	}{
+		// A single request at a time, which is how the provider used to
+		// behave when Sentry did not report a concurrent limit.
+		{name: "serial", maxLimit: 1},
+		{name: "default", concurrentLimit: 0},
+		{name: "concurrent-limit-25", concurrentLimit: 25},
+		{name: "concurrent-limit-4", concurrentLimit: 4},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			srv := fakesentry.NewServer()
+			defer srv.Close()
+			srv.AddOrganization(testUnitOrganization, "Test Organization")
+			for i := 0; i < 200; i++ {
+				srv.AddTeam(testUnitOrganization, fmt.Sprintf("team-%d", i))
+			}
+			srv.SetLatency(5 * time.Millisecond)
+			srv.SetConcurrentLimit(bm.concurrentLimit)
+
+			config := Config{
+				Token:                 "test-token",
+				BaseURL:               srv.BaseURL(),
+				MaxRetries:            4,
+				MinRetryWait:          time.Millisecond,
+				MaxRetryWait:          10 * time.Millisecond,
+				MaxConcurrentRequests: bm.maxLimit,
+			}
+			client, diags := config.Client(context.Background())
+			if diags.HasError() {
+				b.Fatal(diags)
+			}
+
+			// Terraform refreshes up to 10 resources at a time by default.
+			const parallelism = 10
+			work := make(chan int)
+			var wg sync.WaitGroup
+			for w := 0; w < parallelism; w++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := range work {
+						if _, _, err := client.Teams.Get(context.Background(), testUnitOrganization, fmt.Sprintf("team-%d", i%200)); err != nil {
+							b.Error(err)
+						}
+					}
+				}()
+			}
+
+			b.ResetTimer()
+			start := time.Now()
+			for i := 0; i < b.N; i++ {
+				work <- i
+			}
+			close(work)
+			wg.Wait()
+			b.ReportMetric(float64(b.N)/time.Since(start).Seconds(), "refreshes/s")
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package sentry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// defaultResourceTimeout is the least time an operation of a resource is
+// given unless its timeouts block says otherwise. When the retries of a
+// single request may take longer, see Config.retryBudget, operations are
+// given that time instead.
+const defaultResourceTimeout = 5 * time.Minute
+
+// withTimeouts declares configurable create, read, update and delete
+// timeouts on r, named name in the provider, and replaces the diagnostics of
+// an operation that runs out of time with one that names the resource and
+// the operation.
+func withTimeouts(name string, r *schema.Resource) {
+	r.Timeouts = &schema.ResourceTimeout{}
+	setDefaultTimeouts(r, defaultResourceTimeout)
+	r.Description += fmt.Sprintf(" Each operation times out after %s by default, or after the time the "+
+		"retries of a request may take if longer, unless set otherwise in the `timeouts` block.",
+		fmt.Sprintf("%d minutes", int(defaultResourceTimeout.Minutes())))
+
+	wrapOperations(r, func(op string, f operationFunc) operationFunc {
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			diags := f(ctx, d, meta)
+			if !diags.HasError() || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return diags
+			}
+
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Timed out waiting for Sentry to %s %s", op, name),
+				Detail: fmt.Sprintf(
+					"The %s operation of %s did not complete within %s. Sentry may be unavailable or overloaded. Increase the %q timeout in the timeouts block of the resource if it is expected to take longer.",
+					op, name, d.Timeout(op), op,
+				),
+			}}
+		}
+	})
+}
+
+// setDefaultTimeouts sets the timeouts of the operations of r that are not
+// set in its timeouts block. The timeouts set in the block, which the SDK
+// decodes over a copy of these, are left alone.
+func setDefaultTimeouts(r *schema.Resource, timeout time.Duration) {
+	r.Timeouts.Create = &timeout
+	r.Timeouts.Read = &timeout
+	r.Timeouts.Delete = &timeout
+	if r.UpdateContext != nil {
+		r.Timeouts.Update = &timeout
+	}
+}
@@ -0,0 +1,95 @@
+package sentry
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestUnitTimeouts(t *testing.T) {
+	p, srv := testUnitProvider(t)
+	r := newTestUnitResource(t, p, "sentry_team")
+
+	config := map[string]interface{}{
+		"organization": testUnitOrganization,
+		"name":         "tf-team",
+		"timeouts": []interface{}{
+			map[string]interface{}{
+				"create": "100ms",
+			},
+		},
+	}
+
+	srv.SetLatency(time.Second)
+	start := time.Now()
+	diags := r.expectApplyError(config)
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Errorf("expected the request to be abandoned, took %s", elapsed)
+	}
+	if len(diags) != 1 || diags[0].Summary != "Timed out waiting for Sentry to create sentry_team" {
+		t.Fatalf("expected a timeout diagnostic, got %#v", diags)
+	}
+	if want := `within 100ms`; !strings.Contains(diags[0].Detail, want) {
+		t.Errorf("expected detail to contain %q, got %q", want, diags[0].Detail)
+	}
+
+	srv.SetLatency(0)
+	r.apply(config)
+	r.checkAttrs(map[string]string{
+		"name": "tf-team",
+	})
+}
+
+func TestProvider_timeouts(t *testing.T) {
+	p := NewProvider("test")()
+	for name, r := range p.ResourcesMap {
+		if r.Timeouts == nil || r.Timeouts.Create == nil || r.Timeouts.Read == nil || r.Timeouts.Delete == nil {
+			t.Errorf("%s: missing timeouts", name)
+		}
+		if (r.UpdateContext != nil) != (r.Timeouts.Update != nil) {
+			t.Errorf("%s: update timeout does not match update support", name)
+		}
+	}
+}
+
+func TestUnitTimeouts_retryBudget(t *testing.T) {
+	p, _ := testUnitProvider(t)
+	if got := *p.ResourcesMap["sentry_team"].Timeouts.Read; got != defaultResourceTimeout {
+		t.Errorf("got %s, want %s", got, defaultResourceTimeout)
+	}
+
+	// The default timeout leaves time for all the retries of a request.
+	p, _ = testUnitProviderWithConfig(t, map[string]interface{}{
+		"max_retries":     10,
+		"max_retry_wait":  "1m",
+		"request_timeout": "30s",
+	})
+	budget := 15*time.Minute + 30*time.Second
+	if got := *p.ResourcesMap["sentry_team"].Timeouts.Read; got != budget {
+		t.Errorf("got %s, want %s", got, budget)
+	}
+
+	// Timeouts set in the configuration win, whatever their value.
+	r := newTestUnitResource(t, p, "sentry_team")
+	diff := r.plan(map[string]interface{}{
+		"organization": testUnitOrganization,
+		"name":         "tf-team",
+		"timeouts": []interface{}{
+			map[string]interface{}{
+				"create": "24h",
+			},
+		},
+	})
+	var timeouts schema.ResourceTimeout
+	if err := timeouts.DiffDecode(diff); err != nil {
+		t.Fatal(err)
+	}
+	if got := *timeouts.Create; got != 24*time.Hour {
+		t.Errorf("create: got %s, want 24h", got)
+	}
+	if got := *timeouts.Delete; got != budget {
+		t.Errorf("delete: got %s, want %s", got, budget)
+	}
+}
@@ -0,0 +1,106 @@
+package sentry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceTypeKey is the context key of the type of the resource whose
+// operation makes a request.
+type resourceTypeKey struct{}
+
+// withResourceType records the resource type name in the context of each
+// operation of r, so that the requests it makes can be attributed to it.
+func withResourceType(name string, r *schema.Resource) {
+	wrapOperations(r, func(op string, f operationFunc) operationFunc {
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return f(context.WithValue(ctx, resourceTypeKey{}, name), d, meta)
+		}
+	})
+}
+
+// writeGuardTransport rejects requests that would modify Sentry, so that a
+// token with write access can safely be used to plan changes, or to manage
+// a subset of resources only.
+type writeGuardTransport struct {
+	Delegate http.RoundTripper
+
+	// ReadOnly rejects every request that would modify Sentry.
+	ReadOnly bool
+	// WritableResources, if not empty, holds the only resource types whose
+	// operations may modify Sentry.
+	WritableResources map[string]bool
+}
+
+func (t *writeGuardTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return t.Delegate.RoundTrip(req)
+	}
+
+	resourceType, _ := req.Context().Value(resourceTypeKey{}).(string)
+	if t.ReadOnly || (len(t.WritableResources) > 0 && !t.WritableResources[resourceType]) {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, &writeDeniedError{
+			Method:            req.Method,
+			URL:               req.URL.String(),
+			ResourceType:      resourceType,
+			ReadOnly:          t.ReadOnly,
+			WritableResources: t.WritableResources,
+		}
+	}
+	return t.Delegate.RoundTrip(req)
+}
+
+// writeDeniedError is returned for requests rejected by writeGuardTransport.
+type writeDeniedError struct {
+	Method            string
+	URL               string
+	ResourceType      string
+	ReadOnly          bool
+	WritableResources map[string]bool
+}
+
+func (e *writeDeniedError) Error() string {
+	return fmt.Sprintf("%s %s: rejected by the provider, which does not allow this change", e.Method, e.URL)
+}
+
+// diagnostic explains why the request was rejected.
+func (e *writeDeniedError) diagnostic() diag.Diagnostic {
+	if e.ReadOnly {
+		return diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "The Sentry provider is read-only",
+			Detail: fmt.Sprintf(
+				"%s %s was not sent because the provider is configured with `read_only`. Unset `read_only` and the `SENTRY_READ_ONLY` environment variable to apply changes.",
+				e.Method, e.URL,
+			),
+		}
+	}
+
+	resourceType := e.ResourceType
+	if resourceType == "" {
+		resourceType = "This operation"
+	}
+	writable := make([]string, 0, len(e.WritableResources))
+	for name := range e.WritableResources {
+		writable = append(writable, name)
+	}
+	sort.Strings(writable)
+	return diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("%s may not modify Sentry", resourceType),
+		Detail: fmt.Sprintf(
+			"%s %s was not sent because the provider only allows changes by the resource types in `writable_resources`: %s.",
+			e.Method, e.URL, strings.Join(writable, ", "),
+		),
+	}
+}
@@ -0,0 +1,107 @@
+// Command platforms regenerates the platform catalogue embedded in the
+// provider from the Sentry documentation.
+//
+// Usage:
+//
+//	go run ./tools/platforms -o sentry/platforms.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"time"
+)
+
+const defaultIndexURL = "https://docs.sentry.io/_platforms/_index.json"
+
+// platformIndex is the subset of the documentation index that lists the
+// platforms and their guides, keyed by platform and then by guide.
+type platformIndex struct {
+	Platforms map[string]map[string]struct {
+		Key string `json:"key"`
+	} `json:"platforms"`
+}
+
+// extraPlatforms are accepted by Sentry but missing from the documentation
+// index, which only lists the platforms with a guide of their own.
+var extraPlatforms = []string{
+	"apple-tvos",
+	"apple-visionos",
+	"apple-watchos",
+	"go-fiber",
+	"javascript-nuxt",
+	"javascript-solid",
+	"javascript-solidstart",
+	"node-cloudflare-pages",
+	"node-cloudflare-workers",
+	"node-fastify",
+	"node-hapi",
+	"node-nestjs",
+	"other",
+	"powershell",
+}
+
+func main() {
+	indexURL := flag.String("url", defaultIndexURL, "URL of the Sentry documentation platform index")
+	output := flag.String("o", "platforms.json", "output file")
+	flag.Parse()
+
+	platforms, err := fetch(*indexURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	data, err := json.MarshalIndent(platforms, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, append(data, '\n'), 0o644); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("wrote %d platforms to %s", len(platforms), *output)
+}
+
+func fetch(url string) ([]string, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: unexpected status %s", url, resp.Status)
+	}
+
+	var index platformIndex
+	if err := json.NewDecoder(resp.Body).Decode(&index); err != nil {
+		return nil, fmt.Errorf("GET %s: %w", url, err)
+	}
+
+	seen := make(map[string]bool)
+	for _, guides := range index.Platforms {
+		for _, guide := range guides {
+			if guide.Key != "" {
+				seen[guide.Key] = true
+			}
+		}
+	}
+	if len(seen) == 0 {
+		return nil, fmt.Errorf("GET %s: no platforms found", url)
+	}
+	for _, platform := range extraPlatforms {
+		seen[platform] = true
+	}
+
+	platforms := make([]string, 0, len(seen))
+	for platform := range seen {
+		platforms = append(platforms, platform)
+	}
+	sort.Strings(platforms)
+	return platforms, nil
+}